@@ -0,0 +1,96 @@
+package duration
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidAlternative is returned by ParseAlternative when the input isn't in the ISO 8601 alternative format
+var ErrInvalidAlternative = errors.New("invalid alternative format duration")
+
+// ParseAlternative parses a duration in the ISO 8601 alternative format, which is written like a date and time,
+// e.g. P0003-06-04T12:30:05 is 3 years, 6 months, 4 days, 12 hours, 30 minutes and 5 seconds.
+// The date can be shortened to PYYYY-MM, the time to Thh or Thh:mm and the seconds may be fractional.
+// As the standard requires, no value may exceed its carry-over point (12 months, 30 days, 24 hours, 59 minutes and seconds).
+func ParseAlternative(s string) (*Duration, error) {
+	duration := &Duration{}
+	d := strings.TrimSpace(s)
+	invalid := fmt.Errorf("%w: %q", ErrInvalidAlternative, s)
+
+	if strings.HasPrefix(d, "-") {
+		duration.Negative = true
+		d = d[1:]
+	}
+	if !strings.HasPrefix(d, "P") {
+		return nil, invalid
+	}
+	d = d[1:]
+
+	datePart, timePart := d, ""
+	hasTime := false
+	if i := strings.IndexByte(d, 'T'); i != -1 {
+		datePart, timePart = d[:i], d[i+1:]
+		hasTime = true
+	}
+	if datePart == "" && !hasTime {
+		return nil, invalid
+	}
+
+	if datePart != "" {
+		fields := strings.Split(datePart, "-")
+		if len(fields) < 2 || len(fields) > 3 {
+			return nil, invalid
+		}
+		targets := []*float64{&duration.Years, &duration.Months, &duration.Days}
+		widths := []int{4, 2, 2}
+		limits := []float64{9999, 12, 30}
+		for i, field := range fields {
+			value, ok := alternativeField(field, widths[i], limits[i], false)
+			if !ok {
+				return nil, invalid
+			}
+			*targets[i] = value
+		}
+	}
+
+	if hasTime {
+		fields := strings.Split(timePart, ":")
+		if len(fields) > 3 {
+			return nil, invalid
+		}
+		targets := []*float64{&duration.Hours, &duration.Minutes, &duration.Seconds}
+		limits := []float64{24, 59, 59}
+		for i, field := range fields {
+			value, ok := alternativeField(field, 2, limits[i], i == 2)
+			if !ok {
+				return nil, invalid
+			}
+			*targets[i] = value
+		}
+	}
+
+	return duration, nil
+}
+
+// alternativeField parses a fixed width field of the alternative format, only the seconds may have a fraction
+func alternativeField(field string, width int, limit float64, fractional bool) (float64, bool) {
+	whole := field
+	if fractional {
+		if i := strings.IndexAny(field, ".,"); i != -1 {
+			whole = field[:i]
+			field = whole + "." + field[i+1:]
+		}
+	}
+	if len(whole) != width || strings.Trim(whole, "0123456789") != "" {
+		return 0, false
+	}
+
+	value, err := strconv.ParseFloat(field, 64)
+	if err != nil || value > limit {
+		return 0, false
+	}
+
+	return value, true
+}
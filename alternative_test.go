@@ -0,0 +1,57 @@
+package duration
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseAlternative(t *testing.T) {
+	tests := []struct {
+		give    string
+		want    *Duration
+		wantErr bool
+	}{
+		{give: "P0003-06-04T12:30:05", want: &Duration{Years: 3, Months: 6, Days: 4, Hours: 12, Minutes: 30, Seconds: 5}},
+		{give: "P0001-02-03", want: &Duration{Years: 1, Months: 2, Days: 3}},
+		{give: "P2021-12", want: &Duration{Years: 2021, Months: 12}},
+		{give: "-PT01:30", want: &Duration{Hours: 1, Minutes: 30, Negative: true}},
+		{give: "P0000-00-00T00:00:05,5", want: &Duration{Seconds: 5.5}},
+		{give: "P0001-13-01", wantErr: true},
+		{give: "P0001-01-31", wantErr: true},
+		{give: "PT12:60", wantErr: true},
+		{give: "P1-2-3", wantErr: true},
+		{give: "P0001", wantErr: true},
+		{give: "P1Y", wantErr: true},
+		{give: "P", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			got, err := ParseAlternative(tt.give)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseAlternative() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && !errors.Is(err, ErrInvalidAlternative) {
+				t.Errorf("ParseAlternative() error = %v, want %v", err, ErrInvalidAlternative)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseAlternative() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_RejectsAlternative(t *testing.T) {
+	for _, s := range []string{"P2021-12", "P2021-12-31", "PT12:30", "-P0001-02-03"} {
+		t.Run(s, func(t *testing.T) {
+			_, err := Parse(s)
+			if !errors.Is(err, ErrAlternativeFormat) {
+				t.Errorf("Parse() error = %v, want %v", err, ErrAlternativeFormat)
+			}
+			if errors.Is(err, ErrUnexpectedInput) {
+				t.Errorf("Parse() error = %v, want it to be distinct from %v", err, ErrUnexpectedInput)
+			}
+		})
+	}
+}
@@ -0,0 +1,394 @@
+package duration
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// CommonStep returns the greatest common divisor of the given durations' ToTimeDuration totals as a *Duration,
+// that is the largest step which evenly divides all of them. Zero durations are skipped and signs are ignored.
+func CommonStep(durations ...*Duration) *Duration {
+	var step time.Duration
+	for _, duration := range durations {
+		ns := duration.ToTimeDuration()
+		if ns < 0 {
+			ns = -ns
+		}
+		if ns == 0 {
+			continue
+		}
+		step = gcd(step, ns)
+	}
+
+	return FromTimeDuration(step)
+}
+
+// ErrZeroDuration is returned by LCM when one of the durations is zero, which has no multiples
+var ErrZeroDuration = errors.New("zero duration")
+
+// LCM returns the least common multiple of the given durations' ToTimeDuration totals as a *Duration, that is when
+// periodic jobs running at those intervals all coincide again, e.g. PT1H for PT15M and PT20M, or zero without any
+// durations. Signs are ignored, ErrZeroDuration is returned for a zero duration and ErrOverflow when the multiple
+// doesn't fit in a time.Duration.
+func LCM(durations ...*Duration) (*Duration, error) {
+	var multiple time.Duration
+	for _, duration := range durations {
+		ns := duration.ToTimeDuration()
+		if ns < 0 {
+			ns = -ns
+		}
+		if ns == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrZeroDuration, duration)
+		}
+		if multiple == 0 {
+			multiple = ns
+			continue
+		}
+
+		factor := ns / gcd(multiple, ns)
+		if multiple > math.MaxInt64/factor {
+			return nil, fmt.Errorf("%w: least common multiple of %s", ErrOverflow, duration)
+		}
+		multiple *= factor
+	}
+
+	return FromTimeDuration(multiple), nil
+}
+
+// saturate rounds ns to a time.Duration, clamping values that don't fit to ±math.MaxInt64 rather than letting
+// them wrap around, and NaN to zero. The lower bound is -math.MaxInt64 so the result can always be negated.
+func saturate(ns float64) time.Duration {
+	switch {
+	case math.IsNaN(ns):
+		return 0
+	case ns >= math.MaxInt64:
+		return math.MaxInt64
+	case ns <= -math.MaxInt64:
+		return -math.MaxInt64
+	}
+
+	return time.Duration(math.Round(ns))
+}
+
+// gcd returns the greatest common divisor of two non-negative time.Durations
+func gcd(a, b time.Duration) time.Duration {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// CountIn returns how many whole durations fit into total along with the remainder, computed on the ToTimeDuration
+// totals like integer division (e.g. PT15M fits into PT1H7M 4 times with PT7M left over). When duration is zero
+// it fits 0 times and the whole of total is the remainder.
+func (duration *Duration) CountIn(total *Duration) (int, *Duration) {
+	divisor := duration.ToTimeDuration()
+	dividend := total.ToTimeDuration()
+	if divisor == 0 {
+		return 0, FromTimeDuration(dividend)
+	}
+
+	return int(dividend / divisor), FromTimeDuration(dividend % divisor)
+}
+
+// Lerp linearly interpolates between the ToTimeDuration totals of a and b by t, which is clamped to [0, 1],
+// so t of 0 returns a's total and t of 1 returns b's. The sign follows from the interpolated total.
+func Lerp(a, b *Duration, t float64) *Duration {
+	if t < 0 {
+		t = 0
+	}
+	if t > 1 {
+		t = 1
+	}
+
+	from, to := a.ToTimeDuration(), b.ToTimeDuration()
+	if (from < 0) != (to < 0) {
+		// across zero to-from may not fit in a time.Duration, interpolate in float64 instead
+		return FromTimeDuration(saturate(float64(from) + (float64(to)-float64(from))*t))
+	}
+
+	return FromTimeDuration(from + time.Duration(math.Round(float64(to-from)*t)))
+}
+
+// Remap proportionally maps value from a scale of fromMax onto a scale of toMax, computing value * toMax / fromMax
+// on ToTimeDuration totals, e.g. PT30M on a PT1H scale is PT1H on a PT2H scale. A zero duration is returned when
+// fromMax is zero.
+func Remap(value, fromMax, toMax *Duration) *Duration {
+	from := fromMax.ToTimeDuration()
+	if from == 0 {
+		return &Duration{}
+	}
+
+	factor := float64(toMax.ToTimeDuration()) / float64(from)
+	return FromTimeDuration(saturate(float64(value.ToTimeDuration()) * factor))
+}
+
+// Mul returns the ToTimeDuration total of the *Duration multiplied by factor, e.g. PT1M30S times 2 is PT3M.
+// A product beyond what a time.Duration holds (about 292 years) is capped there, and a NaN or infinite factor
+// returns the total unchanged.
+func (duration *Duration) Mul(factor float64) *Duration {
+	total := duration.ToTimeDuration()
+	if math.IsNaN(factor) || math.IsInf(factor, 0) {
+		return FromTimeDuration(total)
+	}
+
+	return FromTimeDuration(saturate(float64(total) * factor))
+}
+
+// Scale returns a copy of the *Duration with every unit multiplied by factor, so calendar units survive unlike Mul,
+// e.g. P1M scaled by 3 is P3M and PT30M scaled by 2 is PT60M (see Normalize to carry it into PT1H). A negative
+// factor flips the sign instead of leaving negative units, a zero result is never negative, and a NaN or infinite
+// factor returns an unchanged copy.
+func (duration *Duration) Scale(factor float64) *Duration {
+	if math.IsNaN(factor) || math.IsInf(factor, 0) {
+		scaled := *duration
+		return &scaled
+	}
+
+	scaled := duration.multiply(math.Abs(factor))
+	scaled.RelativeToRef = duration.RelativeToRef
+	if factor < 0 {
+		scaled.Negative = !scaled.Negative
+	}
+	if scaled.IsZero() {
+		scaled.Negative = false
+	}
+
+	return scaled
+}
+
+// Clamp returns a copy of the *Duration limited to the totals of lower and upper, either of which can be nil
+// to leave that side unbounded. A bound is returned as is when the *Duration falls outside of it.
+func (duration *Duration) Clamp(lower, upper *Duration) *Duration {
+	total := duration.ToTimeDuration()
+	if lower != nil && total < lower.ToTimeDuration() {
+		clamped := *lower
+		return &clamped
+	}
+	if upper != nil && total > upper.ToTimeDuration() {
+		clamped := *upper
+		return &clamped
+	}
+
+	clamped := *duration
+	return &clamped
+}
+
+// Jitter returns the *Duration randomly adjusted by up to ±fraction of its total, e.g. a fraction of 0.1 on PT10S
+// gives something between PT9S and PT11S. The random numbers come from rng so callers control the seed.
+func (duration *Duration) Jitter(fraction float64, rng *rand.Rand) *Duration {
+	return duration.Mul(1 + (rng.Float64()*2-1)*fraction)
+}
+
+// ExponentialSchedule returns a retry backoff schedule of steps durations, base * factor^n for n from 0 to steps-1,
+// each clamped at cap, e.g. PT1S with factor 2 and a cap of PT5S gives PT1S, PT2S, PT4S and PT5S.
+// A nil cap leaves the schedule unbounded, the steps then stop growing at the largest time.Duration.
+func (base *Duration) ExponentialSchedule(factor float64, steps int, cap *Duration) []*Duration {
+	var schedule []*Duration
+	for n := 0; n < steps; n++ {
+		// once a growing schedule reaches the cap every later step is the cap too
+		if n > 0 && cap != nil && factor >= 1 && schedule[n-1].ToTimeDuration() >= cap.ToTimeDuration() {
+			capped := *cap
+			schedule = append(schedule, &capped)
+			continue
+		}
+		schedule = append(schedule, base.Mul(math.Pow(factor, float64(n))).Clamp(nil, cap))
+	}
+
+	return schedule
+}
+
+// MaxComponents returns the per-unit maximum of a and b rather than the larger total, e.g. PT5H and P2DT1H give
+// P2DT5H, for envelope durations across config sources that constrain different units. Signs are ignored, the
+// units are compared as magnitudes and the result is always positive.
+func MaxComponents(a, b *Duration) *Duration {
+	return &Duration{
+		Years:   math.Max(math.Abs(a.Years), math.Abs(b.Years)),
+		Months:  math.Max(math.Abs(a.Months), math.Abs(b.Months)),
+		Weeks:   math.Max(math.Abs(a.Weeks), math.Abs(b.Weeks)),
+		Days:    math.Max(math.Abs(a.Days), math.Abs(b.Days)),
+		Hours:   math.Max(math.Abs(a.Hours), math.Abs(b.Hours)),
+		Minutes: math.Max(math.Abs(a.Minutes), math.Abs(b.Minutes)),
+		Seconds: math.Max(math.Abs(a.Seconds), math.Abs(b.Seconds)),
+	}
+}
+
+// Snap returns a copy of the *Duration with float noise left by arithmetic cleaned up, each unit is rounded to the
+// fewest decimal places (from a whole number up to nanosecond precision) that keep it within epsilon,
+// e.g. 4.9999999999 seconds becomes 5 and 0.30000000000000004 becomes 0.3 at an epsilon of 1e-6.
+// Units that have no such rounding are left alone.
+func (duration *Duration) Snap(epsilon float64) *Duration {
+	snapped := *duration
+	for _, field := range []*float64{
+		&snapped.Years, &snapped.Months, &snapped.Weeks, &snapped.Days,
+		&snapped.Hours, &snapped.Minutes, &snapped.Seconds,
+	} {
+		for places := 0; places <= 9; places++ {
+			scale := math.Pow10(places)
+			rounded := math.Round(*field*scale) / scale
+			if math.Abs(*field-rounded) <= epsilon {
+				*field = rounded
+				break
+			}
+		}
+	}
+
+	return &snapped
+}
+
+// Add returns the sum of the *Duration and other unit by unit, so calendar units survive, e.g. P1Y plus P6M is P1Y6M.
+// The signs are taken into account per unit and the result is negative when no unit is positive. When the units
+// disagree the sign follows the net total and the disagreeing units borrow from larger ones like in written
+// subtraction, using the month and year lengths of ToTimeDuration, e.g. P1Y plus -P6M is P6M and P1D plus -PT1H is PT23H.
+func (duration *Duration) Add(other *Duration) *Duration {
+	a, b := duration.signed(), other.signed()
+	return (&SignedDuration{
+		Years:   a.Years + b.Years,
+		Months:  a.Months + b.Months,
+		Weeks:   a.Weeks + b.Weeks,
+		Days:    a.Days + b.Days,
+		Hours:   a.Hours + b.Hours,
+		Minutes: a.Minutes + b.Minutes,
+		Seconds: a.Seconds + b.Seconds,
+	}).unsigned()
+}
+
+// Sub returns the *Duration minus other unit by unit, see Add for how the signs work out, e.g. PT2H minus PT3H
+// is -PT1H.
+func (duration *Duration) Sub(other *Duration) *Duration {
+	negated := *other
+	negated.Negative = !other.Negative
+	return duration.Add(&negated)
+}
+
+// Accumulate returns the net sum of deltas, which may be negative, added unit by unit with Add.
+// An empty slice sums to zero.
+func Accumulate(deltas []*Duration) *Duration {
+	sum := &Duration{}
+	for _, delta := range deltas {
+		sum = sum.Add(delta)
+	}
+
+	return sum
+}
+
+// AxisTicks returns count+1 evenly spaced durations from zero to span inclusive for labelling a chart axis, that is
+// count intervals, e.g. a count of 4 over PT1H gives PT0S, PT15M, PT30M, PT45M and PT1H. Nil is returned when count
+// isn't positive.
+func (span *Duration) AxisTicks(count int) []*Duration {
+	if count <= 0 {
+		return nil
+	}
+
+	ticks := make([]*Duration, 0, count+1)
+	for i := 0; i <= count; i++ {
+		ticks = append(ticks, span.Mul(float64(i)/float64(count)))
+	}
+
+	return ticks
+}
+
+// niceDurations are the values NiceRound snaps to: 1, 2, 5, 10, 15 and 30 seconds or minutes, 1, 2, 3, 6 and 12 hours,
+// 1 and 2 days or weeks and 1, 3 and 6 months. Below a second and from a year up a 1, 2, 5 series is used instead.
+var niceDurations = []*Duration{
+	{Seconds: 1}, {Seconds: 2}, {Seconds: 5}, {Seconds: 10}, {Seconds: 15}, {Seconds: 30},
+	{Minutes: 1}, {Minutes: 2}, {Minutes: 5}, {Minutes: 10}, {Minutes: 15}, {Minutes: 30},
+	{Hours: 1}, {Hours: 2}, {Hours: 3}, {Hours: 6}, {Hours: 12},
+	{Days: 1}, {Days: 2}, {Weeks: 1}, {Weeks: 2},
+	{Months: 1}, {Months: 3}, {Months: 6},
+	{Years: 1},
+}
+
+// NiceRound snaps the *Duration to the closest human-friendly value for axis labels, like chart libraries do,
+// e.g. PT53M becomes PT1H and PT8M becomes PT10M. See niceDurations for the values, closeness is measured
+// as a ratio so PT40S snaps to PT30S rather than PT1M. The sign is kept and zero stays zero.
+func (duration *Duration) NiceRound() *Duration {
+	total := float64(duration.ToTimeDuration())
+	if total == 0 {
+		return &Duration{}
+	}
+	magnitude := math.Abs(total)
+
+	var nice *Duration
+	switch {
+	case magnitude < nsPerSecond:
+		nice = &Duration{Seconds: niceNumber(magnitude / nsPerSecond)}
+	case magnitude >= nsPerYear:
+		nice = &Duration{Years: niceNumber(magnitude / nsPerYear)}
+	default:
+		best := math.Inf(1)
+		for _, candidate := range niceDurations {
+			if distance := math.Abs(math.Log(magnitude / float64(candidate.ToTimeDuration()))); distance < best {
+				best = distance
+				c := *candidate
+				nice = &c
+			}
+		}
+	}
+
+	nice.Negative = total < 0
+	return nice
+}
+
+// niceNumber returns the value of the 1, 2, 5 series (e.g. 0.1, 0.2, 0.5, 1, 2, 5, 10) closest to x by ratio
+func niceNumber(x float64) float64 {
+	scale := math.Pow(10, math.Floor(math.Log10(x)))
+	best, nice := math.Inf(1), scale
+	for _, step := range []float64{1, 2, 5, 10} {
+		if distance := math.Abs(math.Log(x / (step * scale))); distance < best {
+			best, nice = distance, step*scale
+		}
+	}
+
+	return nice
+}
+
+// NormalizeTime returns a copy of the *Duration with overflowing units carried into the next larger one, seconds
+// into minutes, minutes into hours, hours into days and days into weeks, e.g. PT90M becomes PT1H30M and PT3600S
+// becomes PT1H. Only whole multiples are carried so a fraction stays in the unit it was in, and it stops at weeks
+// since months and years have no exact length, see Normalize to carry into those as well.
+func (duration *Duration) NormalizeTime() *Duration {
+	carried := *duration
+
+	fold := func(from, to *float64, size float64) {
+		if *from >= size {
+			whole := math.Floor(*from / size)
+			*to += whole
+			*from -= whole * size
+		}
+	}
+
+	fold(&carried.Seconds, &carried.Minutes, 60)
+	fold(&carried.Minutes, &carried.Hours, 60)
+	fold(&carried.Hours, &carried.Days, hoursPerDay)
+	fold(&carried.Days, &carried.Weeks, 7)
+
+	return &carried
+}
+
+// Normalize is like NormalizeTime but carries on into months and years, days and weeks that add up to a month
+// or more become months using the same fuzzy month length as ToTimeDuration (730 hours) and 12 months become a year,
+// e.g. P5W becomes P1M4DT14H and P14M becomes P1Y2M.
+func (duration *Duration) Normalize() *Duration {
+	normalized := duration.NormalizeTime()
+
+	hours := normalized.Weeks*hoursPerWeek + normalized.Days*hoursPerDay
+	if hours >= hoursPerMonth {
+		months := math.Floor(hours / hoursPerMonth)
+		normalized.Months += months
+		normalized.Weeks, normalized.Days = 0, 0
+		normalized.Hours += hours - months*hoursPerMonth
+		normalized = normalized.NormalizeTime()
+	}
+
+	if normalized.Months >= 12 {
+		years := math.Floor(normalized.Months / 12)
+		normalized.Years += years
+		normalized.Months -= years * 12
+	}
+
+	return normalized
+}
@@ -0,0 +1,538 @@
+package duration
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestCommonStep(t *testing.T) {
+	tests := []struct {
+		name string
+		give []*Duration
+		want *Duration
+	}{
+		{
+			name: "minutes",
+			give: []*Duration{{Minutes: 30}, {Minutes: 45}},
+			want: &Duration{Minutes: 15},
+		},
+		{
+			name: "skips zero",
+			give: []*Duration{{}, {Hours: 1}, {Minutes: 40}},
+			want: &Duration{Minutes: 20},
+		},
+		{
+			name: "ignores sign",
+			give: []*Duration{{Seconds: 6, Negative: true}, {Seconds: 4}},
+			want: &Duration{Seconds: 2},
+		},
+		{
+			name: "empty",
+			give: nil,
+			want: &Duration{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CommonStep(tt.give...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CommonStep() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLCM(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    []*Duration
+		want    *Duration
+		wantErr error
+	}{
+		{name: "quarter-and-third", give: []*Duration{{Minutes: 15}, {Minutes: 20}}, want: &Duration{Hours: 1}},
+		{name: "three", give: []*Duration{{Seconds: 4}, {Seconds: 6}, {Seconds: 10}}, want: &Duration{Minutes: 1}},
+		{name: "negative", give: []*Duration{{Minutes: 15, Negative: true}, {Minutes: 10}}, want: &Duration{Minutes: 30}},
+		{name: "single", give: []*Duration{{Hours: 2}}, want: &Duration{Hours: 2}},
+		{name: "none", give: nil, want: &Duration{}},
+		{name: "zero", give: []*Duration{{Minutes: 15}, {}}, wantErr: ErrZeroDuration},
+		{name: "overflow", give: []*Duration{{Years: 200}, {Years: 199}}, wantErr: ErrOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LCM(tt.give...)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("LCM() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LCM() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_CountIn(t *testing.T) {
+	tests := []struct {
+		name      string
+		duration  *Duration
+		total     *Duration
+		want      int
+		remainder *Duration
+	}{
+		{
+			name:      "with-remainder",
+			duration:  &Duration{Minutes: 15},
+			total:     &Duration{Hours: 1, Minutes: 7},
+			want:      4,
+			remainder: &Duration{Minutes: 7},
+		},
+		{
+			name:      "exact",
+			duration:  &Duration{Seconds: 90},
+			total:     &Duration{Hours: 1},
+			want:      40,
+			remainder: &Duration{},
+		},
+		{
+			name:      "zero-divisor",
+			duration:  &Duration{},
+			total:     &Duration{Hours: 1},
+			want:      0,
+			remainder: &Duration{Hours: 1},
+		},
+		{
+			name:      "negative-total",
+			duration:  &Duration{Minutes: 15},
+			total:     &Duration{Minutes: 40, Negative: true},
+			want:      -2,
+			remainder: &Duration{Minutes: 10, Negative: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, remainder := tt.duration.CountIn(tt.total)
+			if got != tt.want {
+				t.Errorf("CountIn() got = %d, want %d", got, tt.want)
+			}
+			if !reflect.DeepEqual(remainder, tt.remainder) {
+				t.Errorf("CountIn() remainder = %v, want %v", remainder, tt.remainder)
+			}
+		})
+	}
+}
+
+func TestLerp(t *testing.T) {
+	zero, ten := &Duration{}, &Duration{Seconds: 10}
+	tests := []struct {
+		name string
+		a, b *Duration
+		t    float64
+		want *Duration
+	}{
+		{name: "start", a: zero, b: ten, t: 0, want: &Duration{}},
+		{name: "middle", a: zero, b: ten, t: 0.5, want: &Duration{Seconds: 5}},
+		{name: "end", a: zero, b: ten, t: 1, want: &Duration{Seconds: 10}},
+		{name: "clamp-low", a: zero, b: ten, t: -1, want: &Duration{}},
+		{name: "clamp-high", a: zero, b: ten, t: 2, want: &Duration{Seconds: 10}},
+		{name: "across-zero", a: &Duration{Seconds: 10, Negative: true}, b: ten, t: 0.25, want: &Duration{Seconds: 5, Negative: true}},
+		{name: "extremes", a: FromTimeDuration(-math.MaxInt64), b: FromTimeDuration(math.MaxInt64), t: 0.5, want: &Duration{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Lerp(tt.a, tt.b, tt.t); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lerp() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemap(t *testing.T) {
+	tests := []struct {
+		name                  string
+		value, fromMax, toMax *Duration
+		want                  *Duration
+	}{
+		{name: "double", value: &Duration{Minutes: 30}, fromMax: &Duration{Hours: 1}, toMax: &Duration{Hours: 2}, want: &Duration{Hours: 1}},
+		{name: "shrink", value: &Duration{Hours: 6}, fromMax: &Duration{Days: 1}, toMax: &Duration{Minutes: 4}, want: &Duration{Minutes: 1}},
+		{name: "negative", value: &Duration{Seconds: 10, Negative: true}, fromMax: &Duration{Seconds: 20}, toMax: &Duration{Minutes: 1}, want: &Duration{Seconds: 30, Negative: true}},
+		{name: "zero-scale", value: &Duration{Seconds: 10}, fromMax: &Duration{}, toMax: &Duration{Minutes: 1}, want: &Duration{}},
+		{name: "overflow", value: &Duration{Years: 200}, fromMax: &Duration{Seconds: 1}, toMax: &Duration{Hours: 1}, want: FromTimeDuration(math.MaxInt64)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Remap(tt.value, tt.fromMax, tt.toMax); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Remap() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_Mul(t *testing.T) {
+	tests := []struct {
+		name   string
+		give   *Duration
+		factor float64
+		want   *Duration
+	}{
+		{name: "double", give: &Duration{Minutes: 1, Seconds: 30}, factor: 2, want: &Duration{Minutes: 3}},
+		{name: "half", give: &Duration{Hours: 1}, factor: 0.5, want: &Duration{Minutes: 30}},
+		{name: "negate", give: &Duration{Seconds: 5}, factor: -1, want: &Duration{Seconds: 5, Negative: true}},
+		{name: "overflow", give: &Duration{Years: 200}, factor: 2, want: FromTimeDuration(math.MaxInt64)},
+		{name: "negative-overflow", give: &Duration{Years: 200}, factor: -2, want: FromTimeDuration(-math.MaxInt64)},
+		{name: "infinite", give: &Duration{Seconds: 5}, factor: math.Inf(1), want: &Duration{Seconds: 5}},
+		{name: "nan", give: &Duration{Seconds: 5}, factor: math.NaN(), want: &Duration{Seconds: 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.give.Mul(tt.factor); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Mul() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_Scale(t *testing.T) {
+	tests := []struct {
+		give   string
+		factor float64
+		want   string
+	}{
+		{give: "PT30M", factor: 2, want: "PT60M"},
+		{give: "PT30M", factor: 0.5, want: "PT15M"},
+		{give: "PT30M", factor: -1, want: "-PT30M"},
+		{give: "-P1D", factor: -2, want: "P2D"},
+		{give: "P1Y1M", factor: 3.5, want: "P3.5Y3.5M"},
+		{give: "PT30M", factor: 0, want: "PT0S"},
+		{give: "-PT30M", factor: 0, want: "PT0S"},
+		{give: "-PT30M", factor: math.Copysign(0, -1), want: "PT0S"},
+		{give: "PT30M", factor: math.NaN(), want: "PT30M"},
+		{give: "PT30M", factor: math.Inf(-1), want: "PT30M"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give+"*"+strconv.FormatFloat(tt.factor, 'g', -1, 64), func(t *testing.T) {
+			d, err := Parse(tt.give)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := d.Scale(tt.factor); got.String() != tt.want {
+				t.Errorf("Scale() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if got := (&Duration{Minutes: 30}).Scale(2); !got.NormalizedEqual(&Duration{Hours: 1}) {
+		t.Errorf("Scale() got = %s, want the same as PT1H", got)
+	}
+}
+
+func TestDuration_Clamp(t *testing.T) {
+	lower, upper := &Duration{Seconds: 1}, &Duration{Minutes: 1}
+	tests := []struct {
+		name         string
+		give         *Duration
+		lower, upper *Duration
+		want         *Duration
+	}{
+		{name: "inside", give: &Duration{Seconds: 30}, lower: lower, upper: upper, want: &Duration{Seconds: 30}},
+		{name: "below", give: &Duration{Seconds: 0.5}, lower: lower, upper: upper, want: lower},
+		{name: "above", give: &Duration{Hours: 1}, lower: lower, upper: upper, want: upper},
+		{name: "unbounded", give: &Duration{Hours: 1}, lower: lower, want: &Duration{Hours: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.give.Clamp(tt.lower, tt.upper); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Clamp() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_ExponentialSchedule(t *testing.T) {
+	got := (&Duration{Seconds: 1}).ExponentialSchedule(2, 4, &Duration{Seconds: 5})
+	want := []*Duration{{Seconds: 1}, {Seconds: 2}, {Seconds: 4}, {Seconds: 5}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExponentialSchedule() got = %v, want %v", got, want)
+	}
+
+	if got := (&Duration{Seconds: 1}).ExponentialSchedule(3, 3, nil); !reflect.DeepEqual(got, []*Duration{{Seconds: 1}, {Seconds: 3}, {Seconds: 9}}) {
+		t.Errorf("ExponentialSchedule() uncapped got = %v", got)
+	}
+
+	// 10^n overflows a time.Duration long before the last step
+	capped := (&Duration{Seconds: 1}).ExponentialSchedule(10, 1000, &Duration{Hours: 1})
+	for n, step := range capped[4:] {
+		if !reflect.DeepEqual(step, &Duration{Hours: 1}) {
+			t.Fatalf("ExponentialSchedule() step %d got = %v, want PT1H", n+4, step)
+		}
+	}
+	unbounded := (&Duration{Seconds: 1}).ExponentialSchedule(10, 100, nil)
+	if last := unbounded[len(unbounded)-1]; last.ToTimeDuration() != math.MaxInt64 {
+		t.Errorf("ExponentialSchedule() uncapped last step got = %v, want the largest time.Duration", last)
+	}
+}
+
+func TestMaxComponents(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b *Duration
+		want *Duration
+	}{
+		{name: "hours-and-days", a: &Duration{Hours: 5}, b: &Duration{Days: 2, Hours: 1}, want: &Duration{Days: 2, Hours: 5}},
+		{name: "not-total", a: &Duration{Minutes: 90}, b: &Duration{Hours: 1}, want: &Duration{Hours: 1, Minutes: 90}},
+		{name: "negative", a: &Duration{Seconds: 30, Negative: true}, b: &Duration{Seconds: 10}, want: &Duration{Seconds: 30}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MaxComponents(tt.a, tt.b); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MaxComponents() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_Jitter(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	base := &Duration{Seconds: 10}
+
+	for i := 0; i < 100; i++ {
+		got := base.Jitter(0.1, rng).ToTimeDuration()
+		if got < 9*time.Second || got > 11*time.Second {
+			t.Fatalf("Jitter() got = %v, want between 9s and 11s", got)
+		}
+	}
+
+	first := base.Jitter(0.5, rand.New(rand.NewSource(1)))
+	second := base.Jitter(0.5, rand.New(rand.NewSource(1)))
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("Jitter() with the same seed got = %v and %v", first, second)
+	}
+
+	if got := base.Jitter(0, rng); !reflect.DeepEqual(got, base) {
+		t.Errorf("Jitter() with no fraction got = %v, want %v", got, base)
+	}
+}
+
+func TestDuration_Snap(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    *Duration
+		epsilon float64
+		want    *Duration
+	}{
+		{name: "whole", give: &Duration{Seconds: 4.9999999999}, epsilon: 1e-6, want: &Duration{Seconds: 5}},
+		{name: "decimal", give: &Duration{Hours: 0.30000000000000004, Minutes: 1.2499999999}, epsilon: 1e-6, want: &Duration{Hours: 0.3, Minutes: 1.25}},
+		{name: "real-fraction", give: &Duration{Seconds: 4.9}, epsilon: 1e-6, want: &Duration{Seconds: 4.9}},
+		{name: "negative", give: &Duration{Days: 1.0000000001, Negative: true}, epsilon: 1e-6, want: &Duration{Days: 1, Negative: true}},
+		{name: "too-noisy", give: &Duration{Seconds: 4.99}, epsilon: 1e-6, want: &Duration{Seconds: 4.99}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.give.Snap(tt.epsilon); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Snap() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_Add(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want string
+	}{
+		{a: "P1Y", b: "P6M", want: "P1Y6M"},
+		{a: "PT2H", b: "-PT3H", want: "-PT1H"},
+		{a: "-P1D", b: "-PT12H", want: "-P1DT12H"},
+		{a: "P1Y", b: "-P6M", want: "P6M"},
+		{a: "P1D", b: "-PT1H", want: "PT23H"},
+		{a: "-PT1H", b: "PT90M", want: "PT30M"},
+		{a: "P1DT30M", b: "-PT1H", want: "PT23H30M"},
+		{a: "P1M", b: "-P1D", want: "P4W1.4166666666666667D"},
+		{a: "PT5M", b: "-PT5M", want: "PT0S"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"+"+tt.b, func(t *testing.T) {
+			a, _ := Parse(tt.a)
+			b, _ := Parse(tt.b)
+			got := a.Add(b)
+			if got.String() != tt.want {
+				t.Errorf("Add() got = %s, want %s", got, tt.want)
+			}
+			if _, err := Parse(got.String()); err != nil {
+				t.Errorf("Add() result doesn't parse: %v", err)
+			}
+			if want := a.ToTimeDuration() + b.ToTimeDuration(); got.ToTimeDuration() != want {
+				t.Errorf("Add() total got = %v, want %v", got.ToTimeDuration(), want)
+			}
+			if a.String() != tt.a {
+				t.Errorf("Add() mutated the receiver to %s", a)
+			}
+		})
+	}
+}
+
+func TestDuration_Sub(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want string
+	}{
+		{a: "PT2H", b: "PT3H", want: "-PT1H"},
+		{a: "P1Y6M", b: "P6M", want: "P1Y"},
+		{a: "-P1D", b: "-P3D", want: "P2D"},
+		{a: "PT30M", b: "-PT30M", want: "PT60M"},
+		{a: "P1Y", b: "P1Y", want: "PT0S"},
+		{a: "P1Y", b: "P1Y6M1D", want: "-P6M1D"},
+		{a: "P1Y", b: "P6M", want: "P6M"},
+		{a: "PT1H", b: "PT90M", want: "-PT30M"},
+		{a: "P1D", b: "PT1H", want: "PT23H"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"-"+tt.b, func(t *testing.T) {
+			a, _ := Parse(tt.a)
+			b, _ := Parse(tt.b)
+			got := a.Sub(b)
+			if got.String() != tt.want {
+				t.Errorf("Sub() got = %s, want %s", got, tt.want)
+			}
+			parsed, err := Parse(got.String())
+			if err != nil {
+				t.Errorf("Sub() result doesn't parse: %v", err)
+			} else if !reflect.DeepEqual(parsed, got) {
+				t.Errorf("Sub() result re-parses as %v, want %v", parsed, got)
+			}
+			if a.String() != tt.a || b.String() != tt.b {
+				t.Errorf("Sub() mutated its operands to %s and %s", a, b)
+			}
+		})
+	}
+}
+
+func TestAccumulate(t *testing.T) {
+	tests := []struct {
+		name string
+		give []string
+		want string
+	}{
+		{name: "mixed", give: []string{"P2D", "-P1D", "PT12H"}, want: "P1DT12H"},
+		{name: "net-negative", give: []string{"PT1H", "-PT2H", "-PT30M"}, want: "-PT1H30M"},
+		{name: "borrow", give: []string{"P1D", "-PT2H", "PT30M"}, want: "PT22H30M"},
+		{name: "empty", give: nil, want: "PT0S"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deltas []*Duration
+			for _, s := range tt.give {
+				d, err := Parse(s)
+				if err != nil {
+					t.Fatal(err)
+				}
+				deltas = append(deltas, d)
+			}
+			if got := Accumulate(deltas); got.String() != tt.want {
+				t.Errorf("Accumulate() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_AxisTicks(t *testing.T) {
+	tests := []struct {
+		name  string
+		span  *Duration
+		count int
+		want  []string
+	}{
+		{name: "quarters", span: &Duration{Hours: 1}, count: 4, want: []string{"PT0S", "PT15M", "PT30M", "PT45M", "PT1H"}},
+		{name: "days", span: &Duration{Weeks: 1}, count: 7, want: []string{"PT0S", "P1D", "P2D", "P3D", "P4D", "P5D", "P6D", "P1W"}},
+		{name: "single", span: &Duration{Minutes: 5}, count: 1, want: []string{"PT0S", "PT5M"}},
+		{name: "none", span: &Duration{Minutes: 5}, count: 0, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, tick := range tt.span.AxisTicks(tt.count) {
+				got = append(got, tick.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AxisTicks() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_NiceRound(t *testing.T) {
+	tests := []struct {
+		give string
+		want string
+	}{
+		{give: "PT53M", want: "PT1H"},
+		{give: "PT8M", want: "PT10M"},
+		{give: "PT40S", want: "PT30S"},
+		{give: "PT13M", want: "PT15M"},
+		{give: "PT4H", want: "PT3H"},
+		{give: "P5D", want: "P1W"},
+		{give: "P50D", want: "P1M"},
+		{give: "P3Y", want: "P2Y"},
+		{give: "PT0.3S", want: "PT0.2S"},
+		{give: "-PT7M", want: "-PT5M"},
+		{give: "PT0S", want: "PT0S"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			d, err := Parse(tt.give)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := d.NiceRound(); got.String() != tt.want {
+				t.Errorf("NiceRound() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_Normalize(t *testing.T) {
+	tests := []struct {
+		give string
+		time string
+		full string
+	}{
+		{give: "PT90M", time: "PT1H30M", full: "PT1H30M"},
+		{give: "PT3600S", time: "PT1H", full: "PT1H"},
+		{give: "PT90.5S", time: "PT1M30.5S", full: "PT1M30.5S"},
+		{give: "P1DT25H61M", time: "P2DT2H1M", full: "P2DT2H1M"},
+		{give: "P10D", time: "P1W3D", full: "P1W3D"},
+		{give: "P5W", time: "P5W", full: "P1M4DT14H"},
+		{give: "P14M", time: "P14M", full: "P1Y2M"},
+		{give: "-PT150S", time: "-PT2M30S", full: "-PT2M30S"},
+		{give: "PT0S", time: "PT0S", full: "PT0S"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			d, err := Parse(tt.give)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := d.NormalizeTime()
+			if got.String() != tt.time {
+				t.Errorf("NormalizeTime() got = %s, want %s", got, tt.time)
+			}
+			if again := got.NormalizeTime(); !reflect.DeepEqual(again, got) {
+				t.Errorf("NormalizeTime() isn't idempotent, got = %s then %s", got, again)
+			}
+
+			got = d.Normalize()
+			if got.String() != tt.full {
+				t.Errorf("Normalize() got = %s, want %s", got, tt.full)
+			}
+			if again := got.Normalize(); !reflect.DeepEqual(again, got) {
+				t.Errorf("Normalize() isn't idempotent, got = %s then %s", got, again)
+			}
+			if d.String() != tt.give {
+				t.Errorf("Normalize() mutated the receiver to %s", d)
+			}
+		})
+	}
+}
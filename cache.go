@@ -0,0 +1,108 @@
+package duration
+
+import (
+	"container/list"
+	"sync"
+)
+
+// DefaultParseCacheSize is the number of parsed strings ParseCached remembers unless changed with SetParseCacheSize
+const DefaultParseCacheSize = 256
+
+var parseCache = newLRU(DefaultParseCacheSize)
+
+// ParseCached is like Parse but remembers the most recently parsed strings, which helps hot paths that
+// parse the same values (e.g. common TTLs) over and over. Each call returns a fresh *Duration
+// so mutating the result doesn't affect the cache. It's safe for concurrent use.
+func ParseCached(d string) (*Duration, error) {
+	if cached, ok := parseCache.get(d); ok {
+		return &cached, nil
+	}
+
+	duration, err := Parse(d)
+	if err != nil {
+		return nil, err
+	}
+	parseCache.add(d, *duration)
+
+	return duration, nil
+}
+
+// SetParseCacheSize bounds the number of strings ParseCached remembers, evicting the least recently used ones.
+// A size of zero or less disables the cache.
+func SetParseCacheSize(size int) {
+	parseCache.resize(size)
+}
+
+type lruEntry struct {
+	key   string
+	value Duration
+}
+
+// lru is a concurrency-safe least recently used cache of parsed durations
+type lru struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+func newLRU(size int) *lru {
+	return &lru{
+		size:    size,
+		order:   list.New(),
+		entries: map[string]*list.Element{},
+	}
+}
+
+func (c *lru) get(key string) (Duration, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return Duration{}, false
+	}
+	c.order.MoveToFront(element)
+
+	return element.Value.(*lruEntry).value, true
+}
+
+func (c *lru) add(key string, value Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.size <= 0 {
+		return
+	}
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*lruEntry).value = value
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value})
+	c.evict()
+}
+
+func (c *lru) resize(size int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.size = size
+	c.evict()
+}
+
+// evict drops the least recently used entries until the cache fits its size, callers must hold mu
+func (c *lru) evict() {
+	for c.order.Len() > 0 && c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+func (c *lru) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
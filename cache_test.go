@@ -0,0 +1,84 @@
+package duration
+
+import (
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestParseCached(t *testing.T) {
+	defer SetParseCacheSize(DefaultParseCacheSize)
+
+	first, err := ParseCached("3Y6M4D")
+	if err != nil {
+		t.Fatal(err)
+	}
+	first.Years = 100
+
+	second, err := ParseCached("3Y6M4D")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Duration{Years: 3, Months: 6, Days: 4}
+	if !reflect.DeepEqual(second, want) {
+		t.Errorf("ParseCached() got = %v, want %v", second, want)
+	}
+	if first == second {
+		t.Error("ParseCached() returned the same pointer twice")
+	}
+
+	if _, err := ParseCached("3X"); err == nil {
+		t.Error("expected error for invalid input")
+	}
+
+	SetParseCacheSize(2)
+	for _, s := range []string{"1D", "2D", "3D"} {
+		if _, err := ParseCached(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if parseCache.len() != 2 {
+		t.Errorf("expected cache to hold 2 entries, got %d", parseCache.len())
+	}
+	if _, ok := parseCache.get("1D"); ok {
+		t.Error("expected least recently used entry to be evicted")
+	}
+
+	SetParseCacheSize(0)
+	if parseCache.len() != 0 {
+		t.Errorf("expected disabled cache to be empty, got %d", parseCache.len())
+	}
+	if _, err := ParseCached("4D"); err != nil {
+		t.Fatal(err)
+	}
+	if parseCache.len() != 0 {
+		t.Errorf("expected disabled cache to stay empty, got %d", parseCache.len())
+	}
+}
+
+func TestParseCached_Concurrent(t *testing.T) {
+	defer SetParseCacheSize(DefaultParseCacheSize)
+	SetParseCacheSize(8)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				hours := (i + j) % 12
+				got, err := ParseCached(strconv.Itoa(hours) + "H")
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if got.Hours != float64(hours) {
+					t.Errorf("ParseCached() got = %v hours, want %d", got.Hours, hours)
+				}
+				got.Hours = -1
+			}
+		}(i)
+	}
+	wg.Wait()
+}
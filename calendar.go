@@ -0,0 +1,364 @@
+package duration
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// ErrNonPositiveStep is returned when a step duration that must move forward in time is zero or negative
+var ErrNonPositiveStep = errors.New("step must be positive")
+
+// Now returns the current time for helpers like ParseAndAddToNow, tests can replace it to pin the clock
+var Now = time.Now
+
+// AddTo returns t with the *Duration added, whole years, months, weeks and days are added with time.Time.AddDate
+// so they follow the calendar (e.g. P1M from January 31st lands on March 2nd or 3rd) and the rest is added as elapsed time.
+// Fractional years, months and days fall back to the same fuzzy lengths used by ToTimeDuration.
+// A RelativeToRef duration (e.g. @P1D) is resolved with t as its reference.
+func (duration *Duration) AddTo(t time.Time) time.Time {
+	sign := 1.0
+	if duration.Negative {
+		sign = -1
+	}
+
+	years, yearFraction := math.Modf(duration.Years)
+	months, monthFraction := math.Modf(duration.Months)
+	days, dayFraction := math.Modf(duration.Weeks*7 + duration.Days)
+	t = t.AddDate(int(sign*years), int(sign*months), int(sign*days))
+
+	rest := yearFraction*nsPerYear +
+		monthFraction*nsPerMonth +
+		dayFraction*nsPerDay +
+		duration.Hours*nsPerHour +
+		duration.Minutes*nsPerMinute +
+		duration.Seconds*nsPerSecond
+
+	return t.Add(time.Duration(math.Round(sign * rest)))
+}
+
+// Ticks returns start + n*step for n = 0, 1, 2, ... up to and including end, each tick is computed from start with
+// AddTo rather than from the previous tick so calendar steps like P1M don't drift after a short month.
+// An error is returned when the step is zero or negative since it would never reach end.
+func (step *Duration) Ticks(start, end time.Time) ([]time.Time, error) {
+	if step.Negative || step.ToTimeDuration() <= 0 {
+		return nil, ErrNonPositiveStep
+	}
+
+	var ticks []time.Time
+	for n := 0; ; n++ {
+		tick := step.multiply(float64(n)).AddTo(start)
+		if tick.After(end) {
+			return ticks, nil
+		}
+		ticks = append(ticks, tick)
+	}
+}
+
+// multiply returns a copy of the *Duration with every unit multiplied by factor, the sign is kept as is
+func (duration *Duration) multiply(factor float64) *Duration {
+	return &Duration{
+		Years:    duration.Years * factor,
+		Months:   duration.Months * factor,
+		Weeks:    duration.Weeks * factor,
+		Days:     duration.Days * factor,
+		Hours:    duration.Hours * factor,
+		Minutes:  duration.Minutes * factor,
+		Seconds:  duration.Seconds * factor,
+		Negative: duration.Negative,
+	}
+}
+
+// ParseAndAddToNow parses the duration string s and adds it to the current time from Now with AddTo,
+// e.g. for expiry times, if parsing fails an error is returned instead.
+func ParseAndAddToNow(s string) (time.Time, error) {
+	duration, err := Parse(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return duration.AddTo(Now()), nil
+}
+
+// ToTimeDurationFrom converts the *Duration to the time.Duration that actually elapses when it's added to ref
+// with AddTo, so P1M from January 1st is 31 days while from February 1st it's 28 or 29.
+func (duration *Duration) ToTimeDurationFrom(ref time.Time) time.Duration {
+	return duration.AddTo(ref).Sub(ref)
+}
+
+// Bounds returns the shortest and longest real time the *Duration can take, counting months as 28 to 31 days
+// and years as 365 to 366 days, e.g. 28 and 31 days for P1M. Both bounds are equal without years or months.
+func (duration *Duration) Bounds() (min, max time.Duration) {
+	exact := duration.precise()
+	exact.Negative = false
+	min = exact.ToTimeDuration() + time.Duration(math.Round((duration.Years*365+duration.Months*28)*nsPerDay))
+	max = exact.ToTimeDuration() + time.Duration(math.Round((duration.Years*366+duration.Months*31)*nsPerDay))
+
+	if duration.Negative {
+		return -max, -min
+	}
+
+	return min, max
+}
+
+// At returns the instant fraction of the way through the *Duration starting at start, for timeline scrubbing,
+// e.g. 0.5 through P1D from midnight is noon. The end is found with AddTo and the real time between start and end
+// is interpolated, so halfway through P1M from February 1st is 14 days in. The fraction is clamped to [0, 1].
+func (total *Duration) At(start time.Time, fraction float64) time.Time {
+	if fraction < 0 {
+		fraction = 0
+	}
+	if fraction > 1 {
+		fraction = 1
+	}
+
+	span := total.AddTo(start).Sub(start)
+	return start.Add(time.Duration(math.Round(float64(span) * fraction)))
+}
+
+// DaysFrom returns the number of calendar days between base and base with the *Duration added by AddTo,
+// which resolves years and months exactly for that base, e.g. 28 for P1M from February 1st 2021.
+// Days are counted by date in base's location so a DST change doesn't matter, and the count is negative
+// for negative durations.
+func (duration *Duration) DaysFrom(base time.Time) int {
+	end := duration.AddTo(base).In(base.Location())
+	from := time.Date(base.Year(), base.Month(), base.Day(), 0, 0, 0, 0, time.UTC)
+	to := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+
+	return int(to.Sub(from) / nsPerDay)
+}
+
+// RangeString formats the range from start to start plus the *Duration (resolved with AddTo) using layout,
+// e.g. "2021-01-01 → 2021-01-02" for P1D and the layout "2006-01-02".
+func (duration *Duration) RangeString(start time.Time, layout string) string {
+	return start.Format(layout) + " → " + duration.AddTo(start).Format(layout)
+}
+
+// Decompose splits the *Duration into its calendar part (years, months, weeks and days) and its clock part
+// (hours, minutes and seconds), both carrying the sign, so the first can be applied with time.Time.AddDate
+// and the second as a time.Duration.
+func (duration *Duration) Decompose() (period *Duration, timePart *Duration) {
+	period = &Duration{
+		Years:    duration.Years,
+		Months:   duration.Months,
+		Weeks:    duration.Weeks,
+		Days:     duration.Days,
+		Negative: duration.Negative,
+	}
+	timePart = &Duration{
+		Hours:    duration.Hours,
+		Minutes:  duration.Minutes,
+		Seconds:  duration.Seconds,
+		Negative: duration.Negative,
+	}
+
+	return period, timePart
+}
+
+// Between returns the calendar-aware duration from start to end in years, months, days, hours, minutes and seconds,
+// such that adding it to start with AddTo lands on end. Like time.Time.AddDate, a month from January 31st
+// overflows into March, so someone born on February 29th turns a year older on March 1st in non-leap years.
+// When end is before start the result is negative.
+func Between(start, end time.Time) *Duration {
+	duration := &Duration{}
+	if end.Before(start) {
+		start, end = end, start
+		duration.Negative = true
+	}
+
+	months := (end.Year()-start.Year())*12 + int(end.Month()-start.Month())
+	for months > 0 && start.AddDate(0, months, 0).After(end) {
+		months--
+	}
+	t := start.AddDate(0, months, 0)
+
+	days := int(end.Sub(t) / nsPerDay)
+	for days > 0 && t.AddDate(0, 0, days).After(end) {
+		days--
+	}
+	for !t.AddDate(0, 0, days+1).After(end) {
+		days++
+	}
+	t = t.AddDate(0, 0, days)
+
+	rest := end.Sub(t)
+	duration.Years = float64(months / 12)
+	duration.Months = float64(months % 12)
+	duration.Days = float64(days)
+	duration.Hours = math.Floor(rest.Hours())
+	rest -= time.Duration(duration.Hours) * nsPerHour
+	duration.Minutes = math.Floor(rest.Minutes())
+	rest -= time.Duration(duration.Minutes) * nsPerMinute
+	duration.Seconds = rest.Seconds()
+
+	return duration
+}
+
+// BetweenBusinessHours returns how much of the time from start to end falls within business hours, for SLA clocks.
+// Business hours run from dayStart to dayEnd by the wall clock (in start's location) on the weekdays set in weekdays,
+// so a DST change doesn't move them.
+// The result is in hours, minutes and seconds since business days aren't 24 hours long, and negative when end
+// is before start.
+func BetweenBusinessHours(start, end time.Time, dayStart, dayEnd time.Duration, weekdays map[time.Weekday]bool) *Duration {
+	negative := false
+	if end.Before(start) {
+		start, end = end, start
+		negative = true
+	}
+	end = end.In(start.Location())
+
+	var total time.Duration
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	for day.Before(end) {
+		if weekdays[day.Weekday()] {
+			opening, closing := wallClock(day, dayStart), wallClock(day, dayEnd)
+			if opening.Before(start) {
+				opening = start
+			}
+			if closing.After(end) {
+				closing = end
+			}
+			if closing.After(opening) {
+				total += closing.Sub(opening)
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+
+	duration := &Duration{Negative: negative && total != 0}
+	duration.Hours = math.Floor(total.Hours())
+	total -= time.Duration(duration.Hours) * nsPerHour
+	duration.Minutes = math.Floor(total.Minutes())
+	total -= time.Duration(duration.Minutes) * nsPerMinute
+	duration.Seconds = total.Seconds()
+
+	return duration
+}
+
+// wallClock returns the time offset reads on the wall clock on day's date, e.g. 09:00 for 9 hours even when
+// the clocks change that day, unlike day.Add(offset) from midnight
+func wallClock(day time.Time, offset time.Duration) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), int(offset/time.Hour), int(offset%time.Hour/time.Minute),
+		int(offset%time.Minute/time.Second), int(offset%time.Second), day.Location())
+}
+
+// Age returns the calendar-correct age of someone born at birth as of now, see Between for how
+// leap-day birthdays are handled.
+func Age(birth, now time.Time) *Duration {
+	return Between(birth, now)
+}
+
+// Minimal returns a copy of the *Duration with the fewest non-zero units, useful after Between. The only collapse
+// is days into weeks: when days (plus any weeks) are a clean multiple of 7 and no other unit is set they become
+// weeks, so P14D is P2W while P15D or P7DT1H are left alone.
+func (duration *Duration) Minimal() *Duration {
+	minimal := *duration
+	days := duration.Weeks*7 + duration.Days
+
+	onlyDays := duration.Years == 0 && duration.Months == 0 &&
+		duration.Hours == 0 && duration.Minutes == 0 && duration.Seconds == 0
+	if onlyDays && days != 0 && math.Mod(days, 7) == 0 {
+		minimal.Weeks = days / 7
+		minimal.Days = 0
+	}
+
+	return &minimal
+}
+
+// Floor snaps t down to the closest multiple of the *Duration since the Unix epoch, e.g. to a 15 minute grid
+// for PT15M. Durations with years, months, weeks or days are stepped out from the epoch with AddTo so they
+// follow the calendar (in t's location). The sign is ignored and t is returned as is for a zero duration.
+func (duration *Duration) Floor(t time.Time) time.Time {
+	floor, _ := duration.snap(t)
+	return floor
+}
+
+// Ceil snaps t up to the closest multiple of the *Duration since the Unix epoch, see Floor
+func (duration *Duration) Ceil(t time.Time) time.Time {
+	floor, next := duration.snap(t)
+	if floor.Equal(t) {
+		return t
+	}
+	return next
+}
+
+// NextAligned returns the first time strictly after after that falls on a multiple of the step since midnight
+// in after's location, for cron-like schedules such as every PT15M on the quarter-hour. When the step doesn't divide
+// a day the grid restarts at the next midnight. Calendar steps (with years, months, weeks or days) have no
+// sensible grid within a day, they're aligned to the Unix epoch like Ceil instead. The sign of the step is ignored
+// and after is returned as is for a zero step.
+func (step *Duration) NextAligned(after time.Time) time.Time {
+	positive := *step
+	positive.Negative = false
+	size := positive.ToTimeDuration()
+	if size <= 0 {
+		return after
+	}
+
+	if positive.Years != 0 || positive.Months != 0 || positive.Weeks != 0 || positive.Days != 0 {
+		_, next := positive.snap(after)
+		return next
+	}
+
+	midnight := time.Date(after.Year(), after.Month(), after.Day(), 0, 0, 0, 0, after.Location())
+	next := midnight.Add((after.Sub(midnight)/size + 1) * size)
+	if tomorrow := midnight.AddDate(0, 0, 1); next.After(tomorrow) {
+		return tomorrow
+	}
+
+	return next
+}
+
+// snap returns the grid point at or before t and the one after it
+func (duration *Duration) snap(t time.Time) (floor, next time.Time) {
+	step := *duration
+	step.Negative = false
+	size := step.ToTimeDuration()
+	if size <= 0 {
+		return t, t
+	}
+
+	epoch := time.Unix(0, 0).In(t.Location())
+	elapsed := t.Sub(epoch)
+	n := elapsed / size
+	if elapsed%size < 0 {
+		n--
+	}
+
+	if step.Years == 0 && step.Months == 0 && step.Weeks == 0 && step.Days == 0 {
+		floor = epoch.Add(n * size)
+		return floor, floor.Add(size)
+	}
+
+	// n is only an estimate for calendar steps since their real length varies
+	at := func(n time.Duration) time.Time {
+		return step.multiply(float64(n)).AddTo(epoch)
+	}
+	for at(n).After(t) {
+		n--
+	}
+	for !at(n + 1).After(t) {
+		n++
+	}
+
+	return at(n), at(n + 1)
+}
+
+// Overlaps reports whether the interval starting at start1 lasting d1 overlaps the one starting at start2
+// lasting d2, the ends are found with AddTo. Intervals are half-open so ones that only touch, where one ends
+// exactly when the other starts, don't overlap. A negative duration makes its interval end before it starts.
+func Overlaps(start1 time.Time, d1 *Duration, start2 time.Time, d2 *Duration) bool {
+	from1, to1 := interval(start1, d1)
+	from2, to2 := interval(start2, d2)
+
+	return from1.Before(to2) && from2.Before(to1)
+}
+
+// interval returns the start and end of the interval covered by duration from start, earliest first
+func interval(start time.Time, duration *Duration) (time.Time, time.Time) {
+	end := duration.AddTo(start)
+	if end.Before(start) {
+		return end, start
+	}
+	return start, end
+}
@@ -0,0 +1,473 @@
+package duration
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDuration_AddTo(t *testing.T) {
+	start := time.Date(2021, time.January, 31, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		give *Duration
+		want time.Time
+	}{
+		{
+			name: "month",
+			give: &Duration{Months: 1},
+			want: time.Date(2021, time.March, 3, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "weeks-and-time",
+			give: &Duration{Weeks: 1, Days: 1, Hours: 2, Minutes: 30},
+			want: time.Date(2021, time.February, 8, 12, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "fractional-day",
+			give: &Duration{Days: 1.5},
+			want: time.Date(2021, time.February, 1, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "negative",
+			give: &Duration{Years: 1, Hours: 10, Negative: true},
+			want: time.Date(2020, time.January, 31, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.give.AddTo(start); !got.Equal(tt.want) {
+				t.Errorf("AddTo() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_Ticks(t *testing.T) {
+	start := time.Date(2021, time.January, 1, 9, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 7)
+
+	ticks, err := (&Duration{Days: 1}).Ticks(start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ticks) != 8 {
+		t.Fatalf("expected 8 ticks, got %d", len(ticks))
+	}
+	for i, tick := range ticks {
+		if want := start.AddDate(0, 0, i); !tick.Equal(want) {
+			t.Errorf("tick %d got = %v, want %v", i, tick, want)
+		}
+	}
+
+	ticks, err = (&Duration{Months: 1}).Ticks(time.Date(2021, time.January, 31, 0, 0, 0, 0, time.UTC), time.Date(2021, time.April, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last := ticks[len(ticks)-1]; !last.Equal(time.Date(2021, time.March, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected monthly ticks not to drift, got last tick %v", last)
+	}
+
+	for _, step := range []*Duration{{}, {Days: 1, Negative: true}} {
+		if _, err := step.Ticks(start, end); err != ErrNonPositiveStep {
+			t.Errorf("Ticks() error = %v, want %v", err, ErrNonPositiveStep)
+		}
+	}
+}
+
+func TestParseAndAddToNow(t *testing.T) {
+	defer func(now func() time.Time) { Now = now }(Now)
+	Now = func() time.Time {
+		return time.Date(2021, time.January, 31, 12, 0, 0, 0, time.UTC)
+	}
+
+	got, err := ParseAndAddToNow("P1DT6H")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2021, time.February, 1, 18, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("ParseAndAddToNow() got = %v, want %v", got, want)
+	}
+
+	if _, err := ParseAndAddToNow("P1X"); !errors.Is(err, ErrUnexpectedInput) {
+		t.Errorf("ParseAndAddToNow() error = %v, want %v", err, ErrUnexpectedInput)
+	}
+}
+
+func TestDuration_ToTimeDurationFrom(t *testing.T) {
+	month := &Duration{Months: 1}
+	if got := month.ToTimeDurationFrom(time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC)); got != 29*24*time.Hour {
+		t.Errorf("ToTimeDurationFrom() got = %v, want %v", got, 29*24*time.Hour)
+	}
+	if got := month.ToTimeDurationFrom(time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)); got != 31*24*time.Hour {
+		t.Errorf("ToTimeDurationFrom() got = %v, want %v", got, 31*24*time.Hour)
+	}
+}
+
+func TestDuration_Bounds(t *testing.T) {
+	day := 24 * time.Hour
+	tests := []struct {
+		give     *Duration
+		min, max time.Duration
+	}{
+		{give: &Duration{Months: 1}, min: 28 * day, max: 31 * day},
+		{give: &Duration{Years: 1, Days: 1}, min: 366 * day, max: 367 * day},
+		{give: &Duration{Months: 2, Hours: 12}, min: 56*day + 12*time.Hour, max: 62*day + 12*time.Hour},
+		{give: &Duration{Weeks: 1}, min: 7 * day, max: 7 * day},
+		{give: &Duration{Months: 1, Negative: true}, min: -31 * day, max: -28 * day},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give.String(), func(t *testing.T) {
+			min, max := tt.give.Bounds()
+			if min != tt.min || max != tt.max {
+				t.Errorf("Bounds() got = %v, %v, want %v, %v", min, max, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestDuration_At(t *testing.T) {
+	midnight := time.Date(2021, time.February, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		total    *Duration
+		fraction float64
+		want     time.Time
+	}{
+		{name: "half-day", total: &Duration{Days: 1}, fraction: 0.5, want: midnight.Add(12 * time.Hour)},
+		{name: "half-february", total: &Duration{Months: 1}, fraction: 0.5, want: midnight.AddDate(0, 0, 14)},
+		{name: "quarter-hour", total: &Duration{Hours: 1}, fraction: 0.25, want: midnight.Add(15 * time.Minute)},
+		{name: "negative", total: &Duration{Days: 1, Negative: true}, fraction: 0.5, want: midnight.Add(-12 * time.Hour)},
+		{name: "clamped-low", total: &Duration{Days: 1}, fraction: -1, want: midnight},
+		{name: "clamped-high", total: &Duration{Days: 1}, fraction: 2, want: midnight.AddDate(0, 0, 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.total.At(midnight, tt.fraction); !got.Equal(tt.want) {
+				t.Errorf("At() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_DaysFrom(t *testing.T) {
+	tests := []struct {
+		name string
+		give *Duration
+		base time.Time
+		want int
+	}{
+		{name: "february", give: &Duration{Months: 1}, base: time.Date(2021, time.February, 1, 0, 0, 0, 0, time.UTC), want: 28},
+		{name: "leap-february", give: &Duration{Months: 1}, base: time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC), want: 29},
+		{name: "leap-year", give: &Duration{Years: 1}, base: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC), want: 366},
+		{name: "past-midnight", give: &Duration{Hours: 2}, base: time.Date(2021, time.March, 1, 23, 0, 0, 0, time.UTC), want: 1},
+		{name: "negative", give: &Duration{Months: 1, Negative: true}, base: time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC), want: -28},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.give.DaysFrom(tt.base); got != tt.want {
+				t.Errorf("DaysFrom() got = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_RangeString(t *testing.T) {
+	start := time.Date(2021, time.January, 1, 9, 30, 0, 0, time.UTC)
+	tests := []struct {
+		give   *Duration
+		layout string
+		want   string
+	}{
+		{give: &Duration{Days: 1}, layout: "2006-01-02", want: "2021-01-01 → 2021-01-02"},
+		{give: &Duration{Hours: 2, Minutes: 15}, layout: "15:04", want: "09:30 → 11:45"},
+		{give: &Duration{Months: 1, Negative: true}, layout: time.RFC3339, want: "2021-01-01T09:30:00Z → 2020-12-01T09:30:00Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.give.RangeString(start, tt.layout); got != tt.want {
+				t.Errorf("RangeString() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_Decompose(t *testing.T) {
+	tests := []struct {
+		give, period, timePart string
+	}{
+		{give: "P1Y2DT3H", period: "P1Y2D", timePart: "PT3H"},
+		{give: "-P1W1DT1M1S", period: "-P1W1D", timePart: "-PT1M1S"},
+		{give: "P1M", period: "P1M", timePart: "PT0S"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			duration, err := Parse(tt.give)
+			if err != nil {
+				t.Fatal(err)
+			}
+			period, timePart := duration.Decompose()
+			if period.String() != tt.period {
+				t.Errorf("Decompose() period = %s, want %s", period, tt.period)
+			}
+			if timePart.String() != tt.timePart {
+				t.Errorf("Decompose() time = %s, want %s", timePart, tt.timePart)
+			}
+		})
+	}
+}
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end time.Time
+		want       string
+	}{
+		{
+			name:  "full",
+			start: time.Date(2020, time.January, 15, 8, 0, 0, 0, time.UTC),
+			end:   time.Date(2021, time.March, 20, 10, 30, 15, 0, time.UTC),
+			want:  "P1Y2M5DT2H30M15S",
+		},
+		{
+			name:  "month-end",
+			start: time.Date(2021, time.January, 31, 0, 0, 0, 0, time.UTC),
+			end:   time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC),
+			want:  "P29D",
+		},
+		{
+			name:  "time-before-day",
+			start: time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC),
+			end:   time.Date(2021, time.January, 3, 6, 0, 0, 0, time.UTC),
+			want:  "P1DT18H",
+		},
+		{
+			name:  "negative",
+			start: time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC),
+			end:   time.Date(2021, time.February, 1, 0, 0, 0, 0, time.UTC),
+			want:  "-P1M",
+		},
+		{
+			name:  "same",
+			start: time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC),
+			end:   time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC),
+			want:  "PT0S",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Between(tt.start, tt.end)
+			if got.String() != tt.want {
+				t.Errorf("Between() got = %s, want %s", got, tt.want)
+			}
+			if !got.Negative && !got.AddTo(tt.start).Equal(tt.end) {
+				t.Errorf("AddTo(start) got = %v, want %v", got.AddTo(tt.start), tt.end)
+			}
+		})
+	}
+}
+
+func TestBetweenBusinessHours(t *testing.T) {
+	weekdays := map[time.Weekday]bool{
+		time.Monday: true, time.Tuesday: true, time.Wednesday: true, time.Thursday: true, time.Friday: true,
+	}
+	// March 5th 2021 is a Friday
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2021, time.March, day, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name       string
+		start, end time.Time
+		want       string
+	}{
+		{name: "same-day", start: at(5, 10, 0), end: at(5, 12, 30), want: "PT2H30M"},
+		{name: "before-opening", start: at(5, 7, 0), end: at(5, 10, 0), want: "PT1H"},
+		{name: "over-weekend", start: at(5, 16, 0), end: at(8, 10, 0), want: "PT2H"},
+		{name: "whole-week", start: at(8, 0, 0), end: at(15, 0, 0), want: "PT40H"},
+		{name: "weekend-only", start: at(6, 9, 0), end: at(7, 17, 0), want: "PT0S"},
+		{name: "reversed", start: at(8, 10, 0), end: at(5, 16, 0), want: "-PT2H"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BetweenBusinessHours(tt.start, tt.end, 9*time.Hour, 17*time.Hour, weekdays)
+			if got.String() != tt.want {
+				t.Errorf("BetweenBusinessHours() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBetweenBusinessHours_DST(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	everyDay := map[time.Weekday]bool{}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		everyDay[day] = true
+	}
+
+	// the clocks go forward on March 14th 2021 and back on November 7th 2021
+	for _, start := range []time.Time{
+		time.Date(2021, time.March, 14, 0, 0, 0, 0, newYork),
+		time.Date(2021, time.November, 7, 0, 0, 0, 0, newYork),
+	} {
+		end := time.Date(start.Year(), start.Month(), start.Day(), 10, 0, 0, 0, newYork)
+		if got := BetweenBusinessHours(start, end, 9*time.Hour, 17*time.Hour, everyDay); got.String() != "PT1H" {
+			t.Errorf("BetweenBusinessHours() on %s got = %s, want PT1H", start.Format("2006-01-02"), got)
+		}
+	}
+}
+
+func TestAge(t *testing.T) {
+	birth := time.Date(2000, time.February, 29, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		now  time.Time
+		want string
+	}{
+		{now: time.Date(2001, time.February, 28, 0, 0, 0, 0, time.UTC), want: "P11M30D"},
+		{now: time.Date(2001, time.March, 1, 0, 0, 0, 0, time.UTC), want: "P1Y"},
+		{now: time.Date(2004, time.February, 29, 0, 0, 0, 0, time.UTC), want: "P4Y"},
+		{now: time.Date(2021, time.June, 15, 0, 0, 0, 0, time.UTC), want: "P21Y3M17D"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.now.Format("2006-01-02"), func(t *testing.T) {
+			if got := Age(birth, tt.now); got.String() != tt.want {
+				t.Errorf("Age() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_Minimal(t *testing.T) {
+	tests := []struct {
+		give *Duration
+		want string
+	}{
+		{give: &Duration{Days: 14}, want: "P2W"},
+		{give: &Duration{Days: 15}, want: "P15D"},
+		{give: &Duration{Weeks: 1, Days: 7}, want: "P2W"},
+		{give: &Duration{Days: 7, Hours: 1}, want: "P7DT1H"},
+		{give: &Duration{Months: 1, Days: 7}, want: "P1M7D"},
+		{give: &Duration{Days: 21, Negative: true}, want: "-P3W"},
+		{give: &Duration{}, want: "PT0S"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.give.Minimal(); got.String() != tt.want {
+				t.Errorf("Minimal() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_FloorCeil(t *testing.T) {
+	quarter := &Duration{Minutes: 15}
+	tests := []struct {
+		name        string
+		step        *Duration
+		give        time.Time
+		floor, ceil time.Time
+	}{
+		{
+			name:  "quarter-hour",
+			step:  quarter,
+			give:  time.Date(2021, time.March, 4, 10, 7, 30, 0, time.UTC),
+			floor: time.Date(2021, time.March, 4, 10, 0, 0, 0, time.UTC),
+			ceil:  time.Date(2021, time.March, 4, 10, 15, 0, 0, time.UTC),
+		},
+		{
+			name:  "on-grid",
+			step:  quarter,
+			give:  time.Date(2021, time.March, 4, 10, 45, 0, 0, time.UTC),
+			floor: time.Date(2021, time.March, 4, 10, 45, 0, 0, time.UTC),
+			ceil:  time.Date(2021, time.March, 4, 10, 45, 0, 0, time.UTC),
+		},
+		{
+			name:  "before-epoch",
+			step:  quarter,
+			give:  time.Date(1969, time.December, 31, 23, 50, 0, 0, time.UTC),
+			floor: time.Date(1969, time.December, 31, 23, 45, 0, 0, time.UTC),
+			ceil:  time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "month",
+			step:  &Duration{Months: 1},
+			give:  time.Date(2021, time.March, 4, 10, 7, 0, 0, time.UTC),
+			floor: time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC),
+			ceil:  time.Date(2021, time.April, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "zero",
+			step:  &Duration{},
+			give:  time.Date(2021, time.March, 4, 10, 7, 0, 0, time.UTC),
+			floor: time.Date(2021, time.March, 4, 10, 7, 0, 0, time.UTC),
+			ceil:  time.Date(2021, time.March, 4, 10, 7, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.step.Floor(tt.give); !got.Equal(tt.floor) {
+				t.Errorf("Floor() got = %v, want %v", got, tt.floor)
+			}
+			if got := tt.step.Ceil(tt.give); !got.Equal(tt.ceil) {
+				t.Errorf("Ceil() got = %v, want %v", got, tt.ceil)
+			}
+		})
+	}
+}
+
+func TestDuration_NextAligned(t *testing.T) {
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2021, time.March, day, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name  string
+		step  *Duration
+		after time.Time
+		want  time.Time
+	}{
+		{name: "quarter-hour", step: &Duration{Minutes: 15}, after: at(1, 10, 7), want: at(1, 10, 15)},
+		{name: "on-boundary", step: &Duration{Minutes: 15}, after: at(1, 10, 15), want: at(1, 10, 30)},
+		{name: "past-midnight", step: &Duration{Hours: 1}, after: at(1, 23, 30), want: at(2, 0, 0)},
+		{name: "uneven-step", step: &Duration{Hours: 7}, after: at(1, 22, 0), want: at(2, 0, 0)},
+		{name: "negative-step", step: &Duration{Minutes: 30, Negative: true}, after: at(1, 10, 7), want: at(1, 10, 30)},
+		{name: "calendar", step: &Duration{Days: 1}, after: at(1, 10, 7), want: at(2, 0, 0)},
+		{name: "zero", step: &Duration{}, after: at(1, 10, 7), want: at(1, 10, 7)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.step.NextAligned(tt.after); !got.Equal(tt.want) {
+				t.Errorf("NextAligned() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOverlaps(t *testing.T) {
+	nine := time.Date(2021, time.March, 4, 9, 0, 0, 0, time.UTC)
+	hour := &Duration{Hours: 1}
+	tests := []struct {
+		name   string
+		start1 time.Time
+		d1     *Duration
+		start2 time.Time
+		d2     *Duration
+		want   bool
+	}{
+		{name: "overlapping", start1: nine, d1: hour, start2: nine.Add(30 * time.Minute), d2: hour, want: true},
+		{name: "contained", start1: nine, d1: &Duration{Days: 1}, start2: nine.Add(time.Hour), d2: hour, want: true},
+		{name: "disjoint", start1: nine, d1: hour, start2: nine.Add(2 * time.Hour), d2: hour, want: false},
+		{name: "touching", start1: nine, d1: hour, start2: nine.Add(time.Hour), d2: hour, want: false},
+		{name: "touching-reversed", start1: nine.Add(time.Hour), d1: hour, start2: nine, d2: hour, want: false},
+		{name: "negative", start1: nine, d1: &Duration{Hours: 1, Negative: true}, start2: nine.Add(-30 * time.Minute), d2: &Duration{Minutes: 10}, want: true},
+		{name: "calendar", start1: time.Date(2021, time.January, 31, 0, 0, 0, 0, time.UTC), d1: &Duration{Months: 1}, start2: time.Date(2021, time.March, 2, 0, 0, 0, 0, time.UTC), d2: hour, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Overlaps(tt.start1, tt.d1, tt.start2, tt.d2); got != tt.want {
+				t.Errorf("Overlaps() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
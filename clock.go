@@ -0,0 +1,59 @@
+package duration
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidClock is returned by ParseClock when the input isn't a clock duration such as 01:30:00 or 01:30
+var ErrInvalidClock = errors.New("invalid clock duration")
+
+// ParseClock parses a clock-style duration as written by CountdownString, HH:MM:SS (e.g. 01:30:00) or
+// MM:SS (e.g. 01:30), into a *Duration of hours, minutes and seconds. The hours can have any number of
+// digits, the minutes and seconds must be two digits below 60 and the seconds may be fractional.
+func ParseClock(s string) (*Duration, error) {
+	duration := &Duration{}
+	d := strings.TrimSpace(s)
+	invalid := fmt.Errorf("%w: %q", ErrInvalidClock, s)
+
+	if strings.HasPrefix(d, "-") {
+		duration.Negative = true
+		d = d[1:]
+	}
+
+	fields := strings.Split(d, ":")
+	if len(fields) < 2 || len(fields) > 3 {
+		return nil, invalid
+	}
+
+	targets := []*float64{&duration.Minutes, &duration.Seconds}
+	if len(fields) == 3 {
+		targets = []*float64{&duration.Hours, &duration.Minutes, &duration.Seconds}
+	}
+
+	for i, field := range fields {
+		last := i == len(fields)-1
+		whole := field
+		if last {
+			whole = strings.SplitN(field, ".", 2)[0]
+		}
+		if whole == "" || strings.Trim(whole, "0123456789") != "" {
+			return nil, invalid
+		}
+
+		value, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return nil, invalid
+		}
+		// only the leading field may run past 59, e.g. 90:00 or 100:00:00
+		if i > 0 && (len(whole) != 2 || math.Floor(value) >= 60) {
+			return nil, invalid
+		}
+		*targets[i] = value
+	}
+
+	return duration, nil
+}
@@ -0,0 +1,41 @@
+package duration
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseClock(t *testing.T) {
+	tests := []struct {
+		give    string
+		want    *Duration
+		wantErr bool
+	}{
+		{give: "01:30:00", want: &Duration{Hours: 1, Minutes: 30}},
+		{give: "01:30", want: &Duration{Minutes: 1, Seconds: 30}},
+		{give: "100:00:05.5", want: &Duration{Hours: 100, Seconds: 5.5}},
+		{give: "-00:45", want: &Duration{Seconds: 45, Negative: true}},
+		{give: "01:60:00", wantErr: true},
+		{give: "01:5:00", wantErr: true},
+		{give: "01:30.5:00", wantErr: true},
+		{give: "1:2:3:4", wantErr: true},
+		{give: "90", wantErr: true},
+		{give: "PT1H", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			got, err := ParseClock(tt.give)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseClock() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && !errors.Is(err, ErrInvalidClock) {
+				t.Errorf("ParseClock() error = %v, want %v", err, ErrInvalidClock)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseClock() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package duration
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// compactUnits maps the units understood by ParseCompact to the *Duration field they set and a scale,
+// longer units come first so that "mo" and "ms" win over "m".
+var compactUnits = []struct {
+	unit  string
+	field func(*Duration) *float64
+	scale float64
+}{
+	{"mo", func(d *Duration) *float64 { return &d.Months }, 1},
+	{"ms", func(d *Duration) *float64 { return &d.Seconds }, 1e-3},
+	{"us", func(d *Duration) *float64 { return &d.Seconds }, 1e-6},
+	{"µs", func(d *Duration) *float64 { return &d.Seconds }, 1e-6},
+	{"ns", func(d *Duration) *float64 { return &d.Seconds }, 1e-9},
+	{"y", func(d *Duration) *float64 { return &d.Years }, 1},
+	{"w", func(d *Duration) *float64 { return &d.Weeks }, 1},
+	{"d", func(d *Duration) *float64 { return &d.Days }, 1},
+	{"h", func(d *Duration) *float64 { return &d.Hours }, 1},
+	{"m", func(d *Duration) *float64 { return &d.Minutes }, 1},
+	{"s", func(d *Duration) *float64 { return &d.Seconds }, 1},
+}
+
+// ErrUnknownUnit is returned when a compact duration string has a number followed by an unsupported unit
+var ErrUnknownUnit = errors.New("unknown unit")
+
+// ParseCompact parses a compact token as produced by Compact (e.g. 1d6h30m, 1y2mo or 500ms) into a *Duration,
+// if parsing fails an error is returned instead. Months are "mo" and minutes are "m", sub-second units
+// (ms, us, ns) are added to the seconds.
+func ParseCompact(d string) (*Duration, error) {
+	duration := &Duration{}
+
+	switch {
+	case strings.HasPrefix(d, "-"):
+		duration.Negative = true
+		d = strings.TrimPrefix(d, "-")
+	case strings.HasPrefix(d, "+"):
+		d = strings.TrimPrefix(d, "+")
+	}
+
+	if d == "0" {
+		return duration, nil
+	}
+	if d == "" {
+		return nil, ErrUnexpectedInput
+	}
+
+	for d != "" {
+		end := strings.IndexFunc(d, func(r rune) bool {
+			return !unicode.IsNumber(r) && r != '.'
+		})
+		if end <= 0 {
+			return nil, ErrUnexpectedInput
+		}
+
+		value, err := strconv.ParseFloat(d[:end], 64)
+		if err != nil {
+			return nil, err
+		}
+		d = d[end:]
+
+		matched := false
+		for _, u := range compactUnits {
+			if strings.HasPrefix(d, u.unit) {
+				*u.field(duration) += value * u.scale
+				d = d[len(u.unit):]
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return nil, ErrUnknownUnit
+		}
+	}
+
+	return duration, nil
+}
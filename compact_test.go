@@ -0,0 +1,48 @@
+package duration
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCompact(t *testing.T) {
+	tests := []struct {
+		give    string
+		want    *Duration
+		wantErr bool
+	}{
+		{give: "1d6h30m", want: &Duration{Days: 1, Hours: 6, Minutes: 30}},
+		{give: "1y2mo3d", want: &Duration{Years: 1, Months: 2, Days: 3}},
+		{give: "2mo5m", want: &Duration{Months: 2, Minutes: 5}},
+		{give: "500ms", want: &Duration{Seconds: 0.5}},
+		{give: "1m30s", want: &Duration{Minutes: 1, Seconds: 30}},
+		{give: "-1.5h", want: &Duration{Hours: 1.5, Negative: true}},
+		{give: "0", want: &Duration{}},
+		{give: "", wantErr: true},
+		{give: "5", wantErr: true},
+		{give: "h", wantErr: true},
+		{give: "3x", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			got, err := ParseCompact(tt.give)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseCompact() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCompact() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	for _, s := range []string{"1y2mo3d", "1d6h30m", "-2mo5m1.5s"} {
+		parsed, err := ParseCompact(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed.Compact() != s {
+			t.Errorf("round-trip got = %s, want %s", parsed.Compact(), s)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package duration
+
+import (
+	"errors"
+	"time"
+)
+
+// IsZero reports whether every unit of the *Duration is zero, regardless of its sign
+func (duration *Duration) IsZero() bool {
+	return duration.Years == 0 && duration.Months == 0 && duration.Weeks == 0 && duration.Days == 0 &&
+		duration.Hours == 0 && duration.Minutes == 0 && duration.Seconds == 0
+}
+
+// IsPositive reports whether the *Duration is non-zero and not negative, so exactly one of IsPositive,
+// IsNegative and IsZero is true
+func (duration *Duration) IsPositive() bool {
+	return !duration.Negative && !duration.IsZero()
+}
+
+// IsNegative reports whether the *Duration is non-zero and negative, a negative zero (-PT0S) isn't
+func (duration *Duration) IsNegative() bool {
+	return duration.Negative && !duration.IsZero()
+}
+
+// Equal reports whether the *Duration and other add up to the same ToTimeDuration total, so PT90M equals PT1H30M
+// and P1M equals PT730H.
+func (duration *Duration) Equal(other *Duration) bool {
+	return duration.ToTimeDuration() == other.ToTimeDuration()
+}
+
+// NormalizedEqual reports whether the *Duration and other have the same units once overflowing ones are carried,
+// seconds up to weeks, so PT90M equals PT1H30M and P7D equals P1W. Unlike Equal the fuzzy years and months are
+// compared as written, so P1M doesn't equal P30D or P12M P1Y. Negative durations only equal negative ones,
+// except that zero durations are equal regardless of sign.
+func (duration *Duration) NormalizedEqual(other *Duration) bool {
+	a, b := duration.NormalizeTime(), other.NormalizeTime()
+	if a.IsZero() && b.IsZero() {
+		return true
+	}
+
+	return *a == *b
+}
+
+// EqualPrecise is like Equal but only compares the weeks, days, hours, minutes and seconds,
+// which convert exactly, ignoring the fuzzy years and months.
+func (duration *Duration) EqualPrecise(other *Duration) bool {
+	return duration.precise().ToTimeDuration() == other.precise().ToTimeDuration()
+}
+
+// precise returns a copy of the *Duration without its years and months
+func (duration *Duration) precise() *Duration {
+	precise := *duration
+	precise.Years = 0
+	precise.Months = 0
+	return &precise
+}
+
+// EqualFrom reports whether the *Duration and other take the same real time when added to ref,
+// which makes fuzzy durations comparable, e.g. P1M equals P31D from January 1st but not from February 1st.
+func (duration *Duration) EqualFrom(other *Duration, ref time.Time) bool {
+	return duration.ToTimeDurationFrom(ref) == other.ToTimeDurationFrom(ref)
+}
+
+var (
+	// ErrUnsortedBoundaries is returned by Bucket when the boundaries aren't in ascending order
+	ErrUnsortedBoundaries = errors.New("boundaries must be sorted ascending")
+	// ErrBucketLabels is returned by Bucket when there isn't exactly one more label than boundaries
+	ErrBucketLabels = errors.New("need one label per boundary plus an overflow label")
+)
+
+// Bucket returns the label of the first boundary the *Duration is less than or equal to, comparing totals,
+// or the last label when it's above every boundary, so labels must have one more entry than boundaries.
+// An error is returned when the boundaries aren't sorted ascending or the label count doesn't match.
+func (duration *Duration) Bucket(boundaries []*Duration, labels []string) (string, error) {
+	if len(labels) != len(boundaries)+1 {
+		return "", ErrBucketLabels
+	}
+
+	total := duration.ToTimeDuration()
+	for i, boundary := range boundaries {
+		if i > 0 && boundary.ToTimeDuration() < boundaries[i-1].ToTimeDuration() {
+			return "", ErrUnsortedBoundaries
+		}
+	}
+	for i, boundary := range boundaries {
+		if total <= boundary.ToTimeDuration() {
+			return labels[i], nil
+		}
+	}
+
+	return labels[len(boundaries)], nil
+}
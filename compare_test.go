@@ -0,0 +1,180 @@
+package duration
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDuration_Sign(t *testing.T) {
+	tests := []struct {
+		name                       string
+		give                       *Duration
+		positive, negative, isZero bool
+	}{
+		{name: "positive", give: &Duration{Minutes: 5}, positive: true},
+		{name: "negative", give: &Duration{Days: 1, Negative: true}, negative: true},
+		{name: "zero", give: &Duration{}, isZero: true},
+		{name: "negative-zero", give: &Duration{Negative: true}, isZero: true},
+		{name: "tiny", give: &Duration{Seconds: 1e-12}, positive: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.give.IsPositive(); got != tt.positive {
+				t.Errorf("IsPositive() got = %v, want %v", got, tt.positive)
+			}
+			if got := tt.give.IsNegative(); got != tt.negative {
+				t.Errorf("IsNegative() got = %v, want %v", got, tt.negative)
+			}
+			if got := tt.give.IsZero(); got != tt.isZero {
+				t.Errorf("IsZero() got = %v, want %v", got, tt.isZero)
+			}
+		})
+	}
+}
+
+func TestDuration_NormalizedEqual(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{a: "PT90M", b: "PT1H30M", want: true},
+		{a: "PT3600S", b: "PT1H", want: true},
+		{a: "P7D", b: "P1W", want: true},
+		{a: "PT36H", b: "P1DT12H", want: true},
+		{a: "PT90.5S", b: "PT1M30.5S", want: true},
+		{a: "P1M", b: "P30D", want: false},
+		{a: "P12M", b: "P1Y", want: false},
+		{a: "PT1H", b: "-PT1H", want: false},
+		{a: "PT0S", b: "-PT0S", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"="+tt.b, func(t *testing.T) {
+			a, _ := Parse(tt.a)
+			b, _ := Parse(tt.b)
+			if got := a.NormalizedEqual(b); got != tt.want {
+				t.Errorf("NormalizedEqual() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// stricter than Equal on fuzzy units, looser than comparing the fields
+	months, year := &Duration{Months: 12}, &Duration{Years: 1}
+	if !months.Equal(year) || months.NormalizedEqual(year) {
+		t.Errorf("NormalizedEqual() should tell P12M and P1Y apart where Equal doesn't")
+	}
+	minutes, hours := &Duration{Minutes: 90}, &Duration{Hours: 1, Minutes: 30}
+	if reflect.DeepEqual(minutes, hours) || !minutes.NormalizedEqual(hours) {
+		t.Errorf("NormalizedEqual() should match PT90M and PT1H30M where reflect.DeepEqual doesn't")
+	}
+}
+
+func TestDuration_EqualPrecise(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    *Duration
+		equal   bool
+		precise bool
+	}{
+		{
+			name:    "identical",
+			a:       &Duration{Years: 1, Days: 2},
+			b:       &Duration{Years: 1, Days: 2},
+			equal:   true,
+			precise: true,
+		},
+		{
+			name:    "different-calendar-part",
+			a:       &Duration{Years: 1, Days: 2},
+			b:       &Duration{Months: 3, Hours: 48},
+			equal:   false,
+			precise: true,
+		},
+		{
+			name:    "rollover",
+			a:       &Duration{Minutes: 90},
+			b:       &Duration{Hours: 1, Minutes: 30},
+			equal:   true,
+			precise: true,
+		},
+		{
+			name:    "different-precise-part",
+			a:       &Duration{Months: 1, Days: 1},
+			b:       &Duration{Months: 1, Days: 2},
+			equal:   false,
+			precise: false,
+		},
+		{
+			name:    "sign",
+			a:       &Duration{Days: 1},
+			b:       &Duration{Days: 1, Negative: true},
+			equal:   false,
+			precise: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Equal(tt.b); got != tt.equal {
+				t.Errorf("Equal() got = %v, want %v", got, tt.equal)
+			}
+			if got := tt.a.EqualPrecise(tt.b); got != tt.precise {
+				t.Errorf("EqualPrecise() got = %v, want %v", got, tt.precise)
+			}
+		})
+	}
+}
+
+func TestDuration_EqualFrom(t *testing.T) {
+	month := &Duration{Months: 1}
+	days := &Duration{Days: 31}
+	january := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	february := time.Date(2021, time.February, 1, 0, 0, 0, 0, time.UTC)
+
+	if !month.EqualFrom(days, january) {
+		t.Errorf("expected %s to equal %s from %s", month, days, january)
+	}
+	if month.EqualFrom(days, february) {
+		t.Errorf("expected %s not to equal %s from %s", month, days, february)
+	}
+	if !month.EqualFrom(&Duration{Weeks: 4}, february) {
+		t.Errorf("expected %s to equal P4W from %s", month, february)
+	}
+	if month.Equal(days) {
+		t.Errorf("expected %s not to equal %s without a reference", month, days)
+	}
+}
+
+func TestDuration_Bucket(t *testing.T) {
+	boundaries := []*Duration{{Minutes: 15}, {Hours: 1}, {Hours: 4}}
+	labels := []string{"quick", "normal", "slow", "very slow"}
+
+	tests := []struct {
+		give *Duration
+		want string
+	}{
+		{give: &Duration{Minutes: 5}, want: "quick"},
+		{give: &Duration{Minutes: 15}, want: "quick"},
+		{give: &Duration{Minutes: 45}, want: "normal"},
+		{give: &Duration{Minutes: 90}, want: "slow"},
+		{give: &Duration{Days: 1}, want: "very slow"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give.String(), func(t *testing.T) {
+			got, err := tt.give.Bucket(boundaries, labels)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Bucket() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	duration := &Duration{Minutes: 45}
+	if _, err := duration.Bucket([]*Duration{{Hours: 1}, {Minutes: 15}}, labels[:3]); err != ErrUnsortedBoundaries {
+		t.Errorf("Bucket() error = %v, want %v", err, ErrUnsortedBoundaries)
+	}
+	if _, err := duration.Bucket(boundaries, labels[:3]); err != ErrBucketLabels {
+		t.Errorf("Bucket() error = %v, want %v", err, ErrBucketLabels)
+	}
+}
@@ -0,0 +1,64 @@
+package duration
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// ErrNotCron is returned by ToCron when the *Duration doesn't map cleanly to a cron expression
+var ErrNotCron = errors.New("duration not representable as cron")
+
+// ToCron returns a standard five field cron expression running every *Duration, e.g. 0 * * * * for PT1H or
+// 0 0 * * * for P1D. Time steps must divide their parent unit evenly (PT15M, PT6H) so the schedule doesn't drift,
+// calendar steps are days, a week (on Sunday), months dividing a year or a year, each starting at midnight.
+// Anything else, like PT90M, P2D, negative or mixed calendar and time units, returns ErrNotCron.
+func (duration *Duration) ToCron() (string, error) {
+	invalid := fmt.Errorf("%w: %s", ErrNotCron, duration)
+	if duration.Negative {
+		return "", invalid
+	}
+
+	clock := duration.Hours*60 + duration.Minutes + duration.Seconds/60
+	calendar := 0
+	for _, value := range []float64{duration.Years, duration.Months, duration.Weeks, duration.Days} {
+		if value != 0 {
+			calendar++
+		}
+	}
+
+	switch {
+	case calendar == 0:
+		minutes := int(clock)
+		switch {
+		case float64(minutes) != clock || minutes <= 0:
+			return "", invalid
+		case minutes == 1:
+			return "* * * * *", nil
+		case minutes == 60:
+			return "0 * * * *", nil
+		case 60%minutes == 0:
+			return "*/" + strconv.Itoa(minutes) + " * * * *", nil
+		case minutes == 24*60:
+			return "0 0 * * *", nil
+		case minutes%60 == 0 && 24*60%minutes == 0:
+			return "0 */" + strconv.Itoa(minutes/60) + " * * *", nil
+		}
+	case calendar == 1 && clock == 0:
+		switch {
+		case duration.Days == 1:
+			return "0 0 * * *", nil
+		case duration.Weeks == 1:
+			return "0 0 * * 0", nil
+		case duration.Months == 1:
+			return "0 0 1 * *", nil
+		case duration.Months > 1 && duration.Months == math.Trunc(duration.Months) && 12%int(duration.Months) == 0:
+			return "0 0 1 */" + formatFloat(duration.Months) + " *", nil
+		case duration.Years == 1:
+			return "0 0 1 1 *", nil
+		}
+	}
+
+	return "", invalid
+}
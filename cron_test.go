@@ -0,0 +1,54 @@
+package duration
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDuration_ToCron(t *testing.T) {
+	tests := []struct {
+		give    string
+		want    string
+		wantErr bool
+	}{
+		{give: "PT1M", want: "* * * * *"},
+		{give: "PT15M", want: "*/15 * * * *"},
+		{give: "PT1H", want: "0 * * * *"},
+		{give: "PT60M", want: "0 * * * *"},
+		{give: "PT6H", want: "0 */6 * * *"},
+		{give: "PT24H", want: "0 0 * * *"},
+		{give: "P1D", want: "0 0 * * *"},
+		{give: "P1W", want: "0 0 * * 0"},
+		{give: "P1M", want: "0 0 1 * *"},
+		{give: "P3M", want: "0 0 1 */3 *"},
+		{give: "P1Y", want: "0 0 1 1 *"},
+		{give: "PT90M", wantErr: true},
+		{give: "PT7M", wantErr: true},
+		{give: "PT5H", wantErr: true},
+		{give: "PT30S", wantErr: true},
+		{give: "P2D", wantErr: true},
+		{give: "P5M", wantErr: true},
+		{give: "P1DT1H", wantErr: true},
+		{give: "-PT1H", wantErr: true},
+		{give: "PT0S", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			d, err := Parse(tt.give)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := d.ToCron()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ToCron() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && !errors.Is(err, ErrNotCron) {
+				t.Errorf("ToCron() error = %v, want %v", err, ErrNotCron)
+			}
+			if got != tt.want {
+				t.Errorf("ToCron() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
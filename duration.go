@@ -1,11 +1,14 @@
 package duration
 
 import (
+	"bytes"
 	"database/sql/driver"
+	"encoding/binary"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
@@ -22,6 +25,10 @@ type Duration struct {
 	Minutes  float64
 	Seconds  float64
 	Negative bool
+	// RelativeToRef is set when the duration was written with a leading @ (e.g. @P1D) to mark it as an offset
+	// from a reference time, resolve it with AddTo. Parse only recognises it with ParseOptions.AllowRelative,
+	// String writes the @ back and UnmarshalJSON and Scan always accept it so the flag round-trips.
+	RelativeToRef bool
 }
 
 const (
@@ -42,85 +49,362 @@ const (
 	nsPerYear   = nsPerHour * hoursPerYear
 )
 
+// unit bits are used by the parser to track which units have been seen
+const (
+	unitYears uint8 = 1 << iota
+	unitMonths
+	unitWeeks
+	unitDays
+	unitHours
+	unitMinutes
+	unitSeconds
+)
+
+// binaryVersion is the first byte of the MarshalBinary format, bump it if the layout ever changes
+const binaryVersion = 1
+
+// flags stored in the second byte of the MarshalBinary format
+const (
+	binaryNegative byte = 1 << iota
+	binaryRelativeToRef
+)
+
 var (
 	// ErrUnexpectedInput is returned when an input in the duration string does not match expectations
 	ErrUnexpectedInput = errors.New("unexpected input")
+	// ErrDuplicateUnit is returned when a duration string has the same unit more than once, e.g. P2W1W
+	ErrDuplicateUnit = errors.New("duplicate unit")
+	// ErrAlternativeFormat is returned by Parse for input in the ISO 8601 alternative format (e.g. P0001-02-03),
+	// which has to be parsed with ParseAlternative instead
+	ErrAlternativeFormat = errors.New("alternative format duration, use ParseAlternative")
+	// ErrEmptyInput is returned when a duration string has no components at all, e.g. "", "-" or "P"
+	ErrEmptyInput = errors.New("empty duration")
+	// ErrDanglingTimeSeparator is returned when the T in a duration string isn't followed by any time units, e.g. P1DT
+	ErrDanglingTimeSeparator = errors.New("time separator T without time units")
+	// ErrTooManyComponents is returned when a duration string has more components than ParseOptions.MaxComponents allows
+	ErrTooManyComponents = errors.New("too many components")
+	// ErrFuzzyDuration is returned when a duration with years or months is converted where an exact length is needed
+	ErrFuzzyDuration = errors.New("years and months have no exact length")
+	// ErrOverflow is returned when a duration's total doesn't fit in an int64 of nanoseconds
+	ErrOverflow = errors.New("duration overflows int64 nanoseconds")
+	// ErrInvalidBinary is returned when UnmarshalBinary or GobDecode receive data not produced by MarshalBinary
+	ErrInvalidBinary = errors.New("invalid binary duration")
 )
 
+// ParseError is returned by Parse when the input isn't a valid duration, it records the input and the byte
+// position of the problem while Err holds the underlying cause (e.g. ErrUnexpectedInput or a *strconv.NumError),
+// so errors.Is and errors.As work through it.
+type ParseError struct {
+	Input string
+	Pos   int
+	Err   error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("parsing duration %q: %v at position %d", e.Input, e.Err, e.Pos)
+}
+
+// Unwrap returns the underlying cause of the *ParseError
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ParseOptions tweak how ParseWithOptions reads a duration string, the zero value behaves like Parse
+type ParseOptions struct {
+	// RequirePrefix rejects input without the leading P with ErrMissingPrefix. Without it the legacy format
+	// (e.g. 4Y or 5m, where M is months and m is minutes) is accepted for compatibility, it's deprecated and
+	// a future release will require the P in Parse as well.
+	RequirePrefix bool
+	// MaxComponents caps the number of unit designators in the input, zero means no limit.
+	// It guards against pathological untrusted input such as thousands of repeated designators.
+	MaxComponents int
+	// FractionalDaysAsTime converts a fractional day count into hours, minutes and seconds, so P1.25D
+	// reads as P1DT6H instead of keeping Days at 1.25, as scientific data often encodes time-of-day that way.
+	FractionalDaysAsTime bool
+	// DefaultTrailingUnit is the unit (years, months, weeks, days, hours, minutes or seconds) given to a trailing
+	// number without a designator, e.g. PT1H30 reads as PT1H30S with "seconds". By default it's an error.
+	DefaultTrailingUnit string
+	// TrimChars lists characters to strip from both ends of the input along with whitespace, e.g. "\"'[]{}"
+	// for quoted or bracketed values from various sources
+	TrimChars string
+	// AllowRelative accepts a leading @ (e.g. @P1D) and sets Duration.RelativeToRef
+	AllowRelative bool
+}
+
+// DefaultParseOptions returns the options used by Parse, which for now are the zero value
+func DefaultParseOptions() ParseOptions {
+	return ParseOptions{}
+}
+
+// trailingDesignators maps the units accepted by ParseOptions.DefaultTrailingUnit to their designator,
+// minutes use the lowercase m which means minutes in both the ISO and the legacy format
+var trailingDesignators = map[string]string{
+	"years":   "Y",
+	"months":  "M",
+	"weeks":   "W",
+	"days":    "D",
+	"hours":   "H",
+	"minutes": "m",
+	"seconds": "S",
+}
+
 // Parse attempts to parse the given duration string into a *Duration,
 // if parsing fails an error is returned instead.
 func Parse(d string) (*Duration, error) {
-	duration := &Duration{}
-	num := ""
+	return ParseWithOptions(d, DefaultParseOptions())
+}
+
+// ParseWithOptions is like Parse but lets the caller tweak parsing with options
+func ParseWithOptions(d string, options ParseOptions) (*Duration, error) {
+	duration, err := parse(d, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &duration, nil
+}
+
+// ParseISO is a strict Parse that only accepts ISO 8601 input starting with the P designator (or -P for negatives),
+// so the legacy format without it, e.g. 3Y6M4D12H30m5.5S, is rejected with ErrMissingPrefix.
+func ParseISO(d string) (*Duration, error) {
+	return ParseWithOptions(d, ParseOptions{RequirePrefix: true})
+}
+
+// ParseLenient is a lenient Parse that ignores case entirely, so p1yt5m is accepted. Since case can't be
+// used to tell months from minutes, M (or m) always means months before the T and minutes after it,
+// and without a P there's no time section so it always means months.
+func ParseLenient(d string) (*Duration, error) {
+	duration, err := Parse(strings.ToUpper(d))
+
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) {
+		parseErr.Input = d
+	}
+
+	return duration, err
+}
+
+// ParseDurationOrTime parses s for config fields that accept either a duration (e.g. P1D) or an absolute
+// RFC 3339 time (e.g. 2021-01-01T00:00:00Z), exactly one of the returned values is set. When s is neither
+// the error from parsing it as a duration is returned.
+func ParseDurationOrTime(s string) (*Duration, *time.Time, error) {
+	duration, err := Parse(s)
+	if err == nil {
+		return duration, nil, nil
+	}
+
+	t, timeErr := time.Parse(time.RFC3339Nano, strings.TrimSpace(s))
+	if timeErr != nil {
+		return nil, nil, fmt.Errorf("neither a duration nor an RFC 3339 time: %w", err)
+	}
+
+	return nil, &t, nil
+}
+
+// IsValid reports whether the given duration string would be accepted by Parse,
+// without allocating a *Duration.
+func IsValid(d string) bool {
+	_, err := parse(d, DefaultParseOptions())
+	return err == nil
+}
+
+// parse runs the parsing state machine over d, the number preceding each designator
+// is sliced out of d rather than accumulated so valid input doesn't allocate.
+//
+// Canonical ISO 8601 input starts with P and separates the time units with T, so M means
+// months before the T and minutes after it. Without the P the legacy format is assumed,
+// where M is months and m is minutes.
+func parse(d string, options ParseOptions) (Duration, error) {
+	duration := Duration{}
+	input := d
+	start := 0
+	components := 0
+	var seen, unit uint8
+	state := parsingPeriod
+	separator := 0
+	iso := false
 	var err error
 
+	// values read from files (e.g. YAML block scalars) often carry trailing newlines or tabs,
+	// and quotes or brackets when TrimChars asks for it
+	trim := func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune(options.TrimChars, r)
+	}
+	d = strings.TrimFunc(d, trim)
+	// offset is the position in the input where the loop below starts, so errors can point into the input
+	offset := len(input) - len(strings.TrimLeftFunc(input, trim))
+
+	fail := func(pos int, err error) (Duration, error) {
+		return Duration{}, &ParseError{Input: input, Pos: offset + pos, Err: err}
+	}
+	// unexpected names the offending rune, e.g. unexpected input: 'x'
+	unexpected := func(char rune) error {
+		return fmt.Errorf("%w: %q", ErrUnexpectedInput, char)
+	}
+
+	if options.AllowRelative && strings.HasPrefix(d, "@") {
+		duration.RelativeToRef = true
+		d = strings.TrimPrefix(d, "@")
+		offset++
+	}
+
 	switch {
 	case strings.HasPrefix(d, "-"): // negative duration
 		duration.Negative = true
 		d = strings.TrimPrefix(d, "-") // remove the negative sign
+		offset++
+		// careless formatting sometimes leaves a space between the sign and the duration, e.g. "- P1D"
+		trimmed := strings.TrimLeftFunc(d, unicode.IsSpace)
+		offset += len(d) - len(trimmed)
+		d = trimmed
 	}
 
-	for _, char := range d {
+	if strings.HasPrefix(d, "P") {
+		iso = true
+		d = strings.TrimPrefix(d, "P")
+		offset++
+	} else if options.RequirePrefix {
+		return fail(0, ErrMissingPrefix)
+	}
+
+	for i, char := range d {
+		num := d[start:i]
+		isTime := false
+
 		switch char {
+		case 'T':
+			if !iso || state == parsingTime || num != "" {
+				return fail(i, unexpected(char))
+			}
+			state = parsingTime
+			separator = i
+			start = i + 1
+			continue
 		case 'Y', 'y':
 			duration.Years, err = strconv.ParseFloat(num, 64)
-			if err != nil {
-				return nil, err
-			}
-			num = ""
+			unit = unitYears
 		case 'M':
-			duration.Months, err = strconv.ParseFloat(num, 64)
-			if err != nil {
-				return nil, err
+			if iso && state == parsingTime {
+				duration.Minutes, err = strconv.ParseFloat(num, 64)
+				unit = unitMinutes
+				isTime = true
+			} else {
+				duration.Months, err = strconv.ParseFloat(num, 64)
+				unit = unitMonths
 			}
-			num = ""
 		case 'm':
 			duration.Minutes, err = strconv.ParseFloat(num, 64)
-			if err != nil {
-				return nil, err
-			}
-			num = ""
+			unit = unitMinutes
+			isTime = true
 		case 'W', 'w':
 			duration.Weeks, err = strconv.ParseFloat(num, 64)
-			if err != nil {
-				return nil, err
-			}
-			num = ""
+			unit = unitWeeks
 		case 'D', 'd':
 			duration.Days, err = strconv.ParseFloat(num, 64)
-			if err != nil {
-				return nil, err
-			}
-			num = ""
+			unit = unitDays
 		case 'H', 'h':
 			duration.Hours, err = strconv.ParseFloat(num, 64)
-			if err != nil {
-				return nil, err
-			}
-			num = ""
+			unit = unitHours
+			isTime = true
 		case 'S', 's':
 			duration.Seconds, err = strconv.ParseFloat(num, 64)
-			if err != nil {
-				return nil, err
-			}
-			num = ""
+			unit = unitSeconds
+			isTime = true
 		default:
 			if unicode.IsNumber(char) || char == '.' {
-				num += string(char)
 				continue
 			}
+			// dashes and colons after the P mean a date-like duration such as P2021-12-31
+			if iso && (char == '-' || char == ':') {
+				return fail(i, ErrAlternativeFormat)
+			}
+
+			return fail(i, unexpected(char))
+		}
+
+		if err != nil {
+			return fail(start, err)
+		}
+		components++
+		if options.MaxComponents > 0 && components > options.MaxComponents {
+			return fail(i, ErrTooManyComponents)
+		}
+		// a repeated unit would silently overwrite the earlier value
+		if seen&unit != 0 {
+			return fail(i, fmt.Errorf("%w: %q", ErrDuplicateUnit, char))
+		}
+		seen |= unit
+		// in ISO input the time units must come after the T and the period units before it
+		if iso && isTime != (state == parsingTime) {
+			return fail(i, unexpected(char))
+		}
+		start = i + 1
+	}
+
+	if start < len(d) {
+		designator, ok := trailingDesignators[options.DefaultTrailingUnit]
+		switch {
+		case options.DefaultTrailingUnit == "":
+			return fail(start, fmt.Errorf("%w: trailing number without a unit", ErrUnexpectedInput))
+		case !ok:
+			return fail(start, fmt.Errorf("%w: %q", ErrUnknownUnit, options.DefaultTrailingUnit))
+		case designator == "M" && iso && state == parsingTime:
+			// an M here would be read as minutes
+			return fail(start, fmt.Errorf("%w: trailing number can't be months after the T", ErrUnexpectedInput))
+		}
+
+		// parse again with the designator filled in, the positions in d stay the same
+		options.DefaultTrailingUnit = ""
+		duration, err := parse(input[:offset+len(d)]+designator, options)
+		var parseErr *ParseError
+		if errors.As(err, &parseErr) {
+			parseErr.Input = input
+		}
+		return duration, err
+	}
+
+	if state == parsingTime && seen&(unitHours|unitMinutes|unitSeconds) == 0 {
+		return fail(separator, ErrDanglingTimeSeparator)
+	}
+	// an empty column or field slipping through shouldn't turn into a silent zero
+	if seen == 0 {
+		return fail(len(d), ErrEmptyInput)
+	}
 
-			return nil, ErrUnexpectedInput
+	if options.FractionalDaysAsTime {
+		days, fraction := math.Modf(duration.Days)
+		if fraction != 0 {
+			rest := time.Duration(math.Round(fraction * nsPerDay))
+			duration.Days = days
+			duration.Hours += math.Floor(rest.Hours())
+			rest %= time.Hour
+			duration.Minutes += math.Floor(rest.Minutes())
+			rest %= time.Minute
+			duration.Seconds += rest.Seconds()
 		}
 	}
 
 	return duration, nil
 }
 
+// DecomposeOptions choose which units FromTimeDurationWithOptions extracts, a skipped unit is
+// left in the next smaller one, e.g. 400 days without years and months is P57W1D.
+type DecomposeOptions struct {
+	SkipYears  bool
+	SkipMonths bool
+	SkipWeeks  bool
+}
+
 // FromTimeDuration converts the given time.Duration into duration.Duration.
 // Note that for *Duration's with period values of a month or year that the duration becomes a bit fuzzy
 // since obviously those things vary month to month and year to year.
 func FromTimeDuration(d time.Duration) *Duration {
+	return FromTimeDurationWithOptions(d, DecomposeOptions{})
+}
+
+// FromTimeDurationWithOptions is like FromTimeDuration but lets the caller skip units, e.g. skipping years
+// and months keeps the result exact since only the fuzzy units are avoided.
+func FromTimeDurationWithOptions(d time.Duration, options DecomposeOptions) *Duration {
 	duration := &Duration{}
 	if d == 0 {
 		return duration
@@ -131,15 +415,15 @@ func FromTimeDuration(d time.Duration) *Duration {
 		duration.Negative = true
 	}
 
-	if d.Hours() >= hoursPerYear {
+	if !options.SkipYears && d.Hours() >= hoursPerYear {
 		duration.Years = math.Floor(d.Hours() / hoursPerYear)
 		d -= time.Duration(duration.Years) * nsPerYear
 	}
-	if d.Hours() >= hoursPerMonth {
+	if !options.SkipMonths && d.Hours() >= hoursPerMonth {
 		duration.Months = math.Floor(d.Hours() / hoursPerMonth)
 		d -= time.Duration(duration.Months) * nsPerMonth
 	}
-	if d.Hours() >= hoursPerWeek {
+	if !options.SkipWeeks && d.Hours() >= hoursPerWeek {
 		duration.Weeks = math.Floor(d.Hours() / hoursPerWeek)
 		d -= time.Duration(duration.Weeks) * nsPerWeek
 	}
@@ -168,9 +452,17 @@ func Format(d time.Duration) string {
 	return FromTimeDuration(d).String()
 }
 
+// FormatSeconds formats the given number of seconds into an ISO 8601 duration string (e.g. 90.5 is PT1M30.5S),
+// the seconds are rounded to the nearest nanosecond. Like Format, large values use the fuzzy month and year lengths,
+// and values beyond what a time.Duration holds (about 292 years) are capped there.
+func FormatSeconds(seconds float64) string {
+	return Format(saturate(seconds * nsPerSecond))
+}
+
 // ToTimeDuration converts the *Duration to the standard library's time.Duration.
 // Note that for *Duration's with period values of a month or year that the duration becomes a bit fuzzy
-// since obviously those things vary month to month and year to year.
+// since obviously those things vary month to month and year to year, a month counts as 730 hours
+// and a year as 365 days.
 func (duration *Duration) ToTimeDuration() time.Duration {
 	var timeDuration time.Duration
 
@@ -203,22 +495,35 @@ func (duration *Duration) ToTimeDuration() time.Duration {
 	return timeDuration
 }
 
-// String returns the ISO8601 duration string for the *Duration
+// ToTimeDurationExact is like ToTimeDuration but returns ErrFuzzyDuration rather than guessing
+// when the *Duration has years or months, use ToTimeDurationFrom with a reference date for those.
+func (duration *Duration) ToTimeDurationExact() (time.Duration, error) {
+	if duration.Years != 0 || duration.Months != 0 {
+		return 0, ErrFuzzyDuration
+	}
+
+	return duration.ToTimeDuration(), nil
+}
+
+// String returns the ISO8601 duration string for the *Duration, e.g. P3Y6M4DT12H30M5.5S.
+// Units are written as they are without carrying, so a parsed PT90M formats as PT90M rather than PT1H30M, see Normalize.
+// A RelativeToRef duration keeps its leading @, e.g. @P1D, which Parse only reads back with
+// ParseOptions.AllowRelative.
 func (duration *Duration) String() string {
-	d := ""
+	d := "P"
 	hasTime := false
 
 	appendD := func(designator string, value float64, isTime bool) {
 		if !hasTime && isTime {
-			d += ""
+			d += "T"
 			hasTime = true
 		}
 
-		d += strconv.FormatFloat(value, 'f', -1, 64) + designator
+		d += formatFloat(value) + designator
 	}
 
 	if duration.Years != 0 {
-		appendD("y", duration.Years, false)
+		appendD("Y", duration.Years, false)
 	}
 
 	if duration.Months != 0 {
@@ -226,37 +531,46 @@ func (duration *Duration) String() string {
 	}
 
 	if duration.Weeks != 0 {
-		appendD("w", duration.Weeks, false)
+		appendD("W", duration.Weeks, false)
 	}
 
 	if duration.Days != 0 {
-		appendD("d", duration.Days, false)
+		appendD("D", duration.Days, false)
 	}
 
 	if duration.Hours != 0 {
-		appendD("h", duration.Hours, true)
+		appendD("H", duration.Hours, true)
 	}
 
 	if duration.Minutes != 0 {
-		appendD("m", duration.Minutes, true)
+		appendD("M", duration.Minutes, true)
 	}
 
 	if duration.Seconds != 0 {
-		appendD("s", duration.Seconds, true)
+		appendD("S", duration.Seconds, true)
 	}
 
 	// if the duration is zero, return "PT0S"
-	if d == "" {
-		d += "0s"
+	if d == "P" {
+		d += "T0S"
 	}
 
 	if duration.Negative {
-		return "-" + d
+		d = "-" + d
+	}
+	if duration.RelativeToRef {
+		d = "@" + d
 	}
 
 	return d
 }
 
+// formatFloat formats a unit value the same way for every unit: plain decimal notation without an exponent
+// and without trailing zeros, so 7.0 is 7 and 0.5 is 0.5
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
 // MarshalJSON satisfies the Marshaler interface by return a valid JSON string representation of the duration
 func (duration Duration) MarshalJSON() ([]byte, error) {
 	return json.Marshal(duration.String())
@@ -264,13 +578,21 @@ func (duration Duration) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON satisfies the Unmarshaler interface by return a valid JSON string representation of the duration
 func (duration *Duration) UnmarshalJSON(source []byte) error {
+	// like encoding/json's own types, null is a no-op
+	if bytes.Equal(bytes.TrimSpace(source), []byte("null")) {
+		return nil
+	}
+
 	durationString := ""
 	err := json.Unmarshal(source, &durationString)
 	if err != nil {
 		return err
 	}
 
-	parsed, err := Parse(durationString)
+	// String writes the @ of a RelativeToRef duration, so accept it back
+	options := DefaultParseOptions()
+	options.AllowRelative = true
+	parsed, err := ParseWithOptions(durationString, options)
 	if err != nil {
 		return fmt.Errorf("failed to parse duration: %w", err)
 	}
@@ -279,6 +601,80 @@ func (duration *Duration) UnmarshalJSON(source []byte) error {
 	return nil
 }
 
+// MarshalBinary satisfies the encoding.BinaryMarshaler interface, the layout is a version byte,
+// a flags byte (negative, relative to ref) and then every unit from years to seconds as a big-endian float64
+func (duration Duration) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 2+7*8)
+	data[0] = binaryVersion
+	if duration.Negative {
+		data[1] |= binaryNegative
+	}
+	if duration.RelativeToRef {
+		data[1] |= binaryRelativeToRef
+	}
+
+	for i, value := range []float64{
+		duration.Years, duration.Months, duration.Weeks, duration.Days,
+		duration.Hours, duration.Minutes, duration.Seconds,
+	} {
+		binary.BigEndian.PutUint64(data[2+i*8:], math.Float64bits(value))
+	}
+
+	return data, nil
+}
+
+// UnmarshalBinary satisfies the encoding.BinaryUnmarshaler interface by decoding the MarshalBinary layout
+func (duration *Duration) UnmarshalBinary(data []byte) error {
+	if len(data) != 2+7*8 || data[0] != binaryVersion || data[1]&^(binaryNegative|binaryRelativeToRef) != 0 {
+		return ErrInvalidBinary
+	}
+
+	values := make([]float64, 7)
+	for i := range values {
+		values[i] = math.Float64frombits(binary.BigEndian.Uint64(data[2+i*8:]))
+	}
+
+	*duration = Duration{
+		Years:         values[0],
+		Months:        values[1],
+		Weeks:         values[2],
+		Days:          values[3],
+		Hours:         values[4],
+		Minutes:       values[5],
+		Seconds:       values[6],
+		Negative:      data[1]&binaryNegative != 0,
+		RelativeToRef: data[1]&binaryRelativeToRef != 0,
+	}
+	return nil
+}
+
+// GobEncode satisfies the gob.GobEncoder interface by delegating to MarshalBinary
+func (duration Duration) GobEncode() ([]byte, error) {
+	return duration.MarshalBinary()
+}
+
+// GobDecode satisfies the gob.GobDecoder interface by delegating to UnmarshalBinary
+func (duration *Duration) GobDecode(data []byte) error {
+	return duration.UnmarshalBinary(data)
+}
+
+// EncodeInt64 returns the signed ToTimeDuration total of the *Duration as a sortable integer key, e.g. for a database
+// index, or ErrOverflow when it doesn't fit (about 292 years). The units are lost, decoding gives the same total.
+func (duration *Duration) EncodeInt64() (int64, error) {
+	total := duration.Years*nsPerYear + duration.Months*nsPerMonth + duration.Weeks*nsPerWeek + duration.Days*nsPerDay +
+		duration.Hours*nsPerHour + duration.Minutes*nsPerMinute + duration.Seconds*nsPerSecond
+	if math.Abs(total) >= math.MaxInt64 {
+		return 0, fmt.Errorf("%w: %s", ErrOverflow, duration)
+	}
+
+	return int64(duration.ToTimeDuration()), nil
+}
+
+// DecodeInt64 returns the *Duration for a key produced by EncodeInt64
+func DecodeInt64(ns int64) *Duration {
+	return FromTimeDuration(time.Duration(ns))
+}
+
 // Scan helper to retrieve duration data from postgres
 func (d *Duration) Scan(value interface{}) error {
 	var s string
@@ -287,19 +683,130 @@ func (d *Duration) Scan(value interface{}) error {
 		s = v
 	case []byte:
 		s = string(v)
+	case Duration: // some ORMs hand over values they've already converted
+		*d = v
+		return nil
+	case *Duration:
+		if v == nil {
+			return fmt.Errorf("cannot scan nil %T into duration", value)
+		}
+		*d = *v
+		return nil
 	default:
-		return fmt.Errorf("cannot scan %T into duration", value)
+		parsed, ok, err := scanInterval(value)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("cannot scan %T into duration", value)
+		}
+		*d = *parsed
+		return nil
 	}
 
-	parsed, err := Parse(s)
+	parsed, err := scanString(s)
 	if err != nil {
-		return fmt.Errorf("duration.Parse(%q): %w", s, err)
+		return err
 	}
 	*d = *parsed
 	return nil
 }
 
-// Value helper to insert duration data into postgres
+// scanString detects how a database represents the duration, a plain number is read as seconds (e.g. 3600),
+// otherwise it's parsed as ISO 8601 (e.g. PT1H, or @PT1H as written by Value) and then as a clock
+// (e.g. 01:00:00) when it has a colon.
+// A plain number is checked first since it can never be a valid duration string.
+func scanString(s string) (*Duration, error) {
+	trimmed := strings.TrimSpace(s)
+	if trimmed != "" && strings.Trim(trimmed, "+-.0123456789") == "" {
+		if seconds, err := strconv.ParseFloat(trimmed, 64); err == nil {
+			return &Duration{Seconds: math.Abs(seconds), Negative: seconds < 0}, nil
+		}
+	}
+
+	options := DefaultParseOptions()
+	options.AllowRelative = true
+	parsed, err := ParseWithOptions(s, options)
+	if err == nil {
+		return parsed, nil
+	}
+	if !strings.Contains(s, ":") {
+		return nil, fmt.Errorf("duration.Parse(%q): %w", s, err)
+	}
+
+	clock, clockErr := ParseClock(s)
+	if clockErr != nil {
+		return nil, fmt.Errorf("cannot scan %q as a clock (%v) or as ISO 8601: %w", s, clockErr, err)
+	}
+
+	return clock, nil
+}
+
+// scanInterval converts a pgtype.Interval-like struct, one exposing the fields
+// Microseconds int64, Days int32 and Months int32, into a *Duration.
+// ok is false when the value doesn't have that shape.
+func scanInterval(value interface{}) (duration *Duration, ok bool, err error) {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, false, nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, false, nil
+	}
+
+	microseconds := v.FieldByName("Microseconds")
+	days := v.FieldByName("Days")
+	months := v.FieldByName("Months")
+	if microseconds.Kind() != reflect.Int64 || days.Kind() != reflect.Int32 || months.Kind() != reflect.Int32 {
+		return nil, false, nil
+	}
+
+	// postgres allows each interval field to carry its own sign, a Duration only has one
+	us, dd, mm := microseconds.Int(), days.Int(), months.Int()
+	negative := us < 0 || dd < 0 || mm < 0
+	if negative && (us > 0 || dd > 0 || mm > 0) {
+		return nil, true, fmt.Errorf("cannot scan %T with mixed signs into duration", value)
+	}
+	if negative {
+		us, dd, mm = -us, -dd, -mm
+	}
+
+	return &Duration{
+		Months:   float64(mm),
+		Days:     float64(dd),
+		Seconds:  float64(us) / 1e6,
+		Negative: negative,
+	}, true, nil
+}
+
+// Value helper to insert duration data into postgres, the duration is stored as its
+// canonical ISO 8601 string (e.g. PT5M) so stored values can be compared as text.
 func (duration Duration) Value() (driver.Value, error) {
 	return duration.String(), nil
 }
+
+// SQLInterval returns a Postgres make_interval expression with numbered placeholders along with its args, so
+// queries get a typed interval without going through the text form, e.g. db.Exec("... "+expr, args...).
+// make_interval only takes whole years, months, days, hours and minutes, so fractions are folded down
+// like CanonicalString does and weeks are counted as days. The placeholders are always $1 to $6.
+func (duration *Duration) SQLInterval() (string, []interface{}) {
+	folded := duration.folded()
+	sign := 1.0
+	if duration.Negative {
+		sign = -1
+	}
+
+	args := []interface{}{
+		int64(sign * folded.Years),
+		int64(sign * folded.Months),
+		int64(sign * (folded.Weeks*7 + folded.Days)),
+		int64(sign * folded.Hours),
+		int64(sign * folded.Minutes),
+		sign * folded.Seconds,
+	}
+
+	return "make_interval(years => $1, months => $2, days => $3, hours => $4, mins => $5, secs => $6)", args
+}
@@ -1,9 +1,15 @@
 package duration
 
 import (
+	"bytes"
+	"encoding/gob"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 )
@@ -53,6 +59,71 @@ func TestParse(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "iso-full",
+			args: args{d: "P3Y6M4DT12H30M5.5S"},
+			want: &Duration{
+				Years:   3,
+				Months:  6,
+				Days:    4,
+				Hours:   12,
+				Minutes: 30,
+				Seconds: 5.5,
+			},
+			wantErr: false,
+		},
+		{
+			name: "iso-negative",
+			args: args{d: "-PT5M"},
+			want: &Duration{
+				Minutes:  5,
+				Negative: true,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "iso-time-unit-in-period",
+			args:    args{d: "P5H"},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "time-separator-without-prefix",
+			args:    args{d: "1DT5H"},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "trailing-newline",
+			args: args{d: "1D\n"},
+			want: &Duration{
+				Days: 1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "surrounding-whitespace",
+			args: args{d: "\t 4Y\r\n"},
+			want: &Duration{
+				Years: 4,
+			},
+			wantErr: false,
+		},
+		{
+			name: "space-after-sign",
+			args: args{d: "- P1D"},
+			want: &Duration{
+				Days:     1,
+				Negative: true,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "internal-newline",
+			args:    args{d: "1D\n2H"},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "negative",
 			args: args{d: "-5m"},
@@ -112,6 +183,33 @@ func TestFromTimeDuration(t *testing.T) {
 	}
 }
 
+func TestFromTimeDurationWithOptions(t *testing.T) {
+	days400 := time.Hour * 24 * 400
+	tests := []struct {
+		name    string
+		give    time.Duration
+		options DecomposeOptions
+		want    string
+	}{
+		{name: "default", give: days400, options: DecomposeOptions{}, want: "P1Y1M4DT14H"},
+		{name: "skip-years-and-months", give: days400, options: DecomposeOptions{SkipYears: true, SkipMonths: true}, want: "P57W1D"},
+		{name: "skip-all", give: days400, options: DecomposeOptions{SkipYears: true, SkipMonths: true, SkipWeeks: true}, want: "P400D"},
+		{name: "skip-years", give: days400, options: DecomposeOptions{SkipYears: true}, want: "P13M4DT14H"},
+		{name: "negative", give: -days400 - time.Minute, options: DecomposeOptions{SkipYears: true, SkipMonths: true}, want: "-P57W1DT1M"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FromTimeDurationWithOptions(tt.give, tt.options)
+			if got.String() != tt.want {
+				t.Errorf("FromTimeDurationWithOptions() got = %s, want %s", got, tt.want)
+			}
+			if got.ToTimeDuration() != tt.give {
+				t.Errorf("ToTimeDuration() got = %v, want %v", got.ToTimeDuration(), tt.give)
+			}
+		})
+	}
+}
+
 func TestFormat(t *testing.T) {
 	tests := []struct {
 		give time.Duration
@@ -119,31 +217,31 @@ func TestFormat(t *testing.T) {
 	}{
 		{
 			give: 0,
-			want: "0S",
+			want: "PT0S",
 		},
 		{
 			give: time.Minute * 94,
-			want: "1H34m",
+			want: "PT1H34M",
 		},
 		{
 			give: time.Hour * 72,
-			want: "3D",
+			want: "P3D",
 		},
 		{
 			give: time.Hour * 26,
-			want: "1D2H",
+			want: "P1DT2H",
 		},
 		{
 			give: time.Second * 465461651,
-			want: "14Y9M3D12H54m11S",
+			want: "P14Y9M3DT12H54M11S",
 		},
 		{
 			give: -time.Hour * 99544,
-			want: "-11Y4M1W4D",
+			want: "-P11Y4M1W4D",
 		},
 		{
 			give: -time.Second * 10,
-			want: "-10S",
+			want: "-PT10S",
 		},
 	}
 	for _, tt := range tests {
@@ -156,6 +254,28 @@ func TestFormat(t *testing.T) {
 	}
 }
 
+func TestFormatSeconds(t *testing.T) {
+	tests := []struct {
+		give float64
+		want string
+	}{
+		{give: 0, want: "PT0S"},
+		{give: 90.5, want: "PT1M30.5S"},
+		{give: 3600, want: "PT1H"},
+		{give: 86400.25, want: "P1DT0.25S"},
+		{give: -45, want: "-PT45S"},
+		{give: 1e300, want: Format(math.MaxInt64)},
+		{give: math.Inf(-1), want: Format(-math.MaxInt64)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := FormatSeconds(tt.give); got != tt.want {
+				t.Errorf("FormatSeconds() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestDuration_ToTimeDuration(t *testing.T) {
 	type fields struct {
 		Years    float64
@@ -237,43 +357,59 @@ func TestDuration_ToTimeDuration(t *testing.T) {
 	}
 }
 
+func TestDuration_ToTimeDurationExact(t *testing.T) {
+	got, err := (&Duration{Weeks: 1, Days: 1, Hours: 2, Minutes: 3, Seconds: 4.5, Negative: true}).ToTimeDurationExact()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := -(time.Hour*24*8 + time.Hour*2 + time.Minute*3 + time.Millisecond*4500); got != want {
+		t.Errorf("ToTimeDurationExact() = %v, want %v", got, want)
+	}
+
+	for _, duration := range []*Duration{{Months: 1}, {Years: 0.5, Days: 1}} {
+		if _, err := duration.ToTimeDurationExact(); err != ErrFuzzyDuration {
+			t.Errorf("ToTimeDurationExact() error = %v, want %v", err, ErrFuzzyDuration)
+		}
+	}
+}
+
 func TestDuration_String(t *testing.T) {
-	duration, err := Parse("3Y6M4D12H30m5.5S")
+	duration, err := Parse("P3Y6M4DT12H30M5.5S")
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	if duration.String() != "3Y6M4D12H30m5.5S" {
-		t.Errorf("expected: %s, got: %s", "3Y6M4D12H30m5.5S", duration.String())
+	if duration.String() != "P3Y6M4DT12H30M5.5S" {
+		t.Errorf("expected: %s, got: %s", "P3Y6M4DT12H30M5.5S", duration.String())
 	}
 
 	duration.Seconds = 33.3333
 
-	if duration.String() != "3Y6M4D12H30m33.3333S" {
-		t.Errorf("expected: %s, got: %s", "3Y6M4D12H30m33.3333S", duration.String())
+	if duration.String() != "P3Y6M4DT12H30M33.3333S" {
+		t.Errorf("expected: %s, got: %s", "P3Y6M4DT12H30M33.3333S", duration.String())
 	}
 
-	smallDuration, err := Parse("0.0000000000001S")
+	smallDuration, err := Parse("PT0.0000000000001S")
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	if smallDuration.String() != "0.0000000000001S" {
-		t.Errorf("expected: %s, got: %s", "0.0000000000001S", smallDuration.String())
+	if smallDuration.String() != "PT0.0000000000001S" {
+		t.Errorf("expected: %s, got: %s", "PT0.0000000000001S", smallDuration.String())
 	}
 
-	negativeDuration, err := Parse("-2H5m")
+	negativeDuration, err := Parse("-PT2H5M")
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	if negativeDuration.String() != "-2H5m" {
-		t.Errorf("expected: %s, got: %s", "-2H5m", negativeDuration.String())
+	if negativeDuration.String() != "-PT2H5M" {
+		t.Errorf("expected: %s, got: %s", "-PT2H5M", negativeDuration.String())
 	}
 }
 
 func TestDuration_MarshalJSON(t *testing.T) {
-	td, err := Parse("3Y6M4D12H30m5.5S")
+	td, err := Parse("P3Y6M4DT12H30M5.5S")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -284,8 +420,8 @@ func TestDuration_MarshalJSON(t *testing.T) {
 	if err != nil {
 		t.Errorf("did not expect error: %s", err.Error())
 	}
-	if string(jsonVal) != `{"d":"3Y6M4D12H30m5.5S"}` {
-		t.Errorf("expected: %s, got: %s", `{"d":"3Y6M4D12H30m5.5S"}`, string(jsonVal))
+	if string(jsonVal) != `{"d":"P3Y6M4DT12H30M5.5S"}` {
+		t.Errorf("expected: %s, got: %s", `{"d":"P3Y6M4DT12H30M5.5S"}`, string(jsonVal))
 	}
 
 	jsonVal, err = json.Marshal(struct {
@@ -294,18 +430,18 @@ func TestDuration_MarshalJSON(t *testing.T) {
 	if err != nil {
 		t.Errorf("did not expect error: %s", err.Error())
 	}
-	if string(jsonVal) != `{"d":"3Y6M4D12H30m5.5S"}` {
-		t.Errorf("expected: %s, got: %s", `{"d":"3Y6M4D12H30m5.5S"}`, string(jsonVal))
+	if string(jsonVal) != `{"d":"P3Y6M4DT12H30M5.5S"}` {
+		t.Errorf("expected: %s, got: %s", `{"d":"P3Y6M4DT12H30M5.5S"}`, string(jsonVal))
 	}
 }
 
 func TestDuration_UnmarshalJSON(t *testing.T) {
 	jsonStr := `
 		{
-			"d": "3Y6M4D12H30m5.5S"
+			"d": "P3Y6M4DT12H30M5.5S"
 		}
 	`
-	expected, err := Parse("3Y6M4D12H30m5.5S")
+	expected, err := Parse("P3Y6M4DT12H30M5.5S")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -331,4 +467,900 @@ func TestDuration_UnmarshalJSON(t *testing.T) {
 	if !reflect.DeepEqual(durStruct.Dur, *expected) {
 		t.Errorf("JSON Unmarshal ptr got = %s, want %s", &(durStruct.Dur), expected)
 	}
+
+	durStruct.Dur = Duration{}
+	if err := json.Unmarshal([]byte(`{"d":null}`), &durStruct); err != nil {
+		t.Errorf("did not expect error for null: %s", err.Error())
+	}
+	if !reflect.DeepEqual(durStruct.Dur, Duration{}) {
+		t.Errorf("JSON Unmarshal null got = %s, want a zero duration", &durStruct.Dur)
+	}
+}
+
+func TestDuration_ScanInterval(t *testing.T) {
+	// interval mirrors the shape of pgtype.Interval
+	type interval struct {
+		Microseconds int64
+		Days         int32
+		Months       int32
+		Valid        bool
+	}
+
+	tests := []struct {
+		name    string
+		give    interface{}
+		want    Duration
+		wantErr bool
+	}{
+		{
+			name: "positive",
+			give: interval{Microseconds: 5500000, Days: 4, Months: 6, Valid: true},
+			want: Duration{Months: 6, Days: 4, Seconds: 5.5},
+		},
+		{
+			name: "pointer",
+			give: &interval{Microseconds: 250000, Valid: true},
+			want: Duration{Seconds: 0.25},
+		},
+		{
+			name: "negative",
+			give: interval{Microseconds: -1000000, Days: -2},
+			want: Duration{Days: 2, Seconds: 1, Negative: true},
+		},
+		{
+			name:    "mixed-signs",
+			give:    interval{Microseconds: 1000000, Days: -2},
+			wantErr: true,
+		},
+		{
+			name:    "wrong-shape",
+			give:    struct{ Days int }{Days: 1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Duration
+			err := got.Scan(tt.give)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Scan() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Scan() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	corpus := []string{
+		"", "-", "4Y", "2.5S", "3Y6M4D12H30m5.5S", "-5m", "0SP0D", "1W2d", "1..5S", "S", "3X", "P1D", "1Y2", "٣D",
+	}
+	for _, s := range corpus {
+		t.Run(s, func(t *testing.T) {
+			_, err := Parse(s)
+			if got := IsValid(s); got != (err == nil) {
+				t.Errorf("IsValid() = %v, but Parse() error = %v", got, err)
+			}
+		})
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		IsValid("3Y6M4D12H30m5.5S")
+	})
+	if allocs != 0 {
+		t.Errorf("IsValid() allocated %v times, want 0", allocs)
+	}
+}
+
+func TestDuration_Value(t *testing.T) {
+	value, err := Duration{Minutes: 5}.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != "PT5M" {
+		t.Errorf("expected: %s, got: %s", "PT5M", value)
+	}
+
+	var scanned Duration
+	if err := scanned.Scan(value); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(scanned, Duration{Minutes: 5}) {
+		t.Errorf("Scan() got = %v, want %v", scanned, Duration{Minutes: 5})
+	}
+}
+
+func TestDuration_SQLInterval(t *testing.T) {
+	tests := []struct {
+		give *Duration
+		want []interface{}
+	}{
+		{give: &Duration{Years: 1, Months: 2, Weeks: 1, Days: 3, Hours: 4, Minutes: 5, Seconds: 6.5}, want: []interface{}{int64(1), int64(2), int64(10), int64(4), int64(5), 6.5}},
+		{give: &Duration{Days: 1.5, Negative: true}, want: []interface{}{int64(0), int64(0), int64(-1), int64(-12), int64(0), 0.0}},
+		{give: &Duration{Months: 1.5}, want: []interface{}{int64(0), int64(1), int64(15), int64(5), int64(0), 0.0}},
+		{give: &Duration{}, want: []interface{}{int64(0), int64(0), int64(0), int64(0), int64(0), 0.0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give.String(), func(t *testing.T) {
+			sql, args := tt.give.SQLInterval()
+			if want := "make_interval(years => $1, months => $2, days => $3, hours => $4, mins => $5, secs => $6)"; sql != want {
+				t.Errorf("SQLInterval() sql = %s, want %s", sql, want)
+			}
+			if !reflect.DeepEqual(args, tt.want) {
+				t.Errorf("SQLInterval() args = %v, want %v", args, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_GobEncode(t *testing.T) {
+	type payload struct {
+		Ptr *Duration
+		Val Duration
+	}
+
+	ptr, err := Parse("P3Y6M4DT12H30M5.5S")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := payload{Ptr: ptr, Val: Duration{Weeks: 2, Seconds: 0.25, Negative: true}}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(want); err != nil {
+		t.Fatal(err)
+	}
+
+	var got payload
+	if err := gob.NewDecoder(&buf).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("gob round-trip got = %v, want %v", got, want)
+	}
+
+	if err := got.Val.GobDecode([]byte{binaryVersion, 0}); err != ErrInvalidBinary {
+		t.Errorf("GobDecode() error = %v, want %v", err, ErrInvalidBinary)
+	}
+}
+
+func TestDuration_EncodeInt64(t *testing.T) {
+	for _, s := range []string{"PT0S", "PT1H30M", "-P2DT0.5S", "P1Y2M3W"} {
+		t.Run(s, func(t *testing.T) {
+			d, err := Parse(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ns, err := d.EncodeInt64()
+			if err != nil {
+				t.Fatalf("did not expect error: %s", err.Error())
+			}
+			if ns != int64(d.ToTimeDuration()) {
+				t.Errorf("EncodeInt64() got = %d, want %d", ns, int64(d.ToTimeDuration()))
+			}
+			if got := DecodeInt64(ns); !got.Equal(d) {
+				t.Errorf("DecodeInt64() got = %s, want %s", got, d)
+			}
+		})
+	}
+
+	short, _ := (&Duration{Hours: 1}).EncodeInt64()
+	long, _ := (&Duration{Days: 1}).EncodeInt64()
+	if short >= long {
+		t.Errorf("EncodeInt64() keys don't sort, PT1H = %d, P1D = %d", short, long)
+	}
+
+	for _, d := range []*Duration{{Years: 300}, {Years: 300, Negative: true}} {
+		if _, err := d.EncodeInt64(); !errors.Is(err, ErrOverflow) {
+			t.Errorf("EncodeInt64() error = %v, want %v", err, ErrOverflow)
+		}
+	}
+}
+
+func TestParseWithOptions_MaxComponents(t *testing.T) {
+	options := ParseOptions{MaxComponents: 3}
+
+	if _, err := ParseWithOptions("P3Y6M4D", options); err != nil {
+		t.Errorf("did not expect error: %s", err.Error())
+	}
+
+	if _, err := ParseWithOptions("P3Y6M4DT12H30M5.5S", options); !errors.Is(err, ErrTooManyComponents) {
+		t.Errorf("ParseWithOptions() error = %v, want %v", err, ErrTooManyComponents)
+	}
+
+	if _, err := ParseWithOptions(strings.Repeat("1Y", 10000), ParseOptions{MaxComponents: 1}); !errors.Is(err, ErrTooManyComponents) {
+		t.Errorf("ParseWithOptions() error = %v, want %v", err, ErrTooManyComponents)
+	}
+}
+
+func TestDuration_StringRoundTrip(t *testing.T) {
+	// Parse keeps components as written and String reproduces them, nothing is carried into larger units
+	for _, s := range []string{
+		"PT90M",
+		"P400D",
+		"PT36H",
+		"P1Y13M",
+		"P2W",
+		"PT3600S",
+		"P1DT25H61M",
+		"-PT0.5S",
+		"PT0S",
+	} {
+		t.Run(s, func(t *testing.T) {
+			duration, err := Parse(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if duration.String() != s {
+				t.Errorf("expected: %s, got: %s", s, duration.String())
+			}
+		})
+	}
+}
+
+func TestFormat_ParseRoundTrip(t *testing.T) {
+	// Format output must be strict ISO 8601 that Parse reads back to the same time.Duration
+	for _, d := range []time.Duration{
+		0,
+		time.Second * 5,
+		time.Minute * 94,
+		time.Hour * 26,
+		time.Hour * 24 * 21,
+		time.Second * 465461651,
+		-time.Hour * 99544,
+		time.Millisecond * 1500,
+	} {
+		t.Run(d.String(), func(t *testing.T) {
+			s := Format(d)
+			parsed, err := Parse(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := parsed.ToTimeDuration(); got != d {
+				t.Errorf("Parse(Format()) got = %v, want %v", got, d)
+			}
+			if errs := Validate(s); parsed.Weeks == 0 && errs != nil {
+				t.Errorf("Format() got = %s, which isn't strict ISO 8601: %v", s, errs)
+			}
+		})
+	}
+}
+
+func TestDuration_StringFloatFormatting(t *testing.T) {
+	tests := []struct {
+		give *Duration
+		want string
+	}{
+		{give: &Duration{Years: 2.0}, want: "P2Y"},
+		{give: &Duration{Months: 1.5}, want: "P1.5M"},
+		{give: &Duration{Weeks: 0.25}, want: "P0.25W"},
+		{give: &Duration{Days: 7.0}, want: "P7D"},
+		{give: &Duration{Hours: 100000000}, want: "PT100000000H"},
+		{give: &Duration{Minutes: 0.5}, want: "PT0.5M"},
+		{give: &Duration{Seconds: 0.000001}, want: "PT0.000001S"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.give.String(); got != tt.want {
+				t.Errorf("expected: %s, got: %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParse_DuplicateUnit(t *testing.T) {
+	for _, s := range []string{"P2W1W", "P1W1w", "P1Y2Y", "PT1M2M", "1m2m", "P1DT1H1D"} {
+		t.Run(s, func(t *testing.T) {
+			_, err := Parse(s)
+			if !errors.Is(err, ErrDuplicateUnit) {
+				t.Errorf("Parse() error = %v, want %v", err, ErrDuplicateUnit)
+			}
+		})
+	}
+
+	_, err := Parse("P2W1W")
+	if err == nil || err.Error() != `parsing duration "P2W1W": duplicate unit: 'W' at position 4` {
+		t.Errorf("Parse() error = %v", err)
+	}
+
+	// months and minutes are different units even though they share a designator
+	if _, err := Parse("P1MT1M"); err != nil {
+		t.Errorf("did not expect error: %s", err.Error())
+	}
+}
+
+func TestDuration_ScanString(t *testing.T) {
+	tests := []struct {
+		give    interface{}
+		want    Duration
+		wantErr bool
+	}{
+		{give: "PT1H", want: Duration{Hours: 1}},
+		{give: "01:30:00", want: Duration{Hours: 1, Minutes: 30}},
+		{give: []byte("3600"), want: Duration{Seconds: 3600}},
+		{give: "-2.5", want: Duration{Seconds: 2.5, Negative: true}},
+		{give: "P1H", wantErr: true},
+		{give: "01:99:00", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.give), func(t *testing.T) {
+			var got Duration
+			err := got.Scan(tt.give)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Scan() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && !errors.Is(err, ErrUnexpectedInput) {
+				t.Errorf("Scan() error = %v, want %v", err, ErrUnexpectedInput)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Scan() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_ScanDuration(t *testing.T) {
+	source := Duration{Days: 1, Hours: 6, Negative: true}
+
+	var fromValue Duration
+	if err := fromValue.Scan(source); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(fromValue, source) {
+		t.Errorf("Scan() got = %v, want %v", fromValue, source)
+	}
+
+	var fromPointer Duration
+	if err := fromPointer.Scan(&source); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(fromPointer, source) {
+		t.Errorf("Scan() got = %v, want %v", fromPointer, source)
+	}
+
+	fromPointer.Hours = 12
+	if source.Hours != 6 {
+		t.Error("Scan() aliased the source duration")
+	}
+
+	var fromNil Duration
+	if err := fromNil.Scan((*Duration)(nil)); err == nil {
+		t.Error("expected error scanning a nil *Duration")
+	}
+}
+
+func TestParseWithOptions_RequirePrefix(t *testing.T) {
+	if DefaultParseOptions().RequirePrefix {
+		t.Fatalf("DefaultParseOptions() RequirePrefix = true, want false")
+	}
+
+	got, err := ParseWithOptions("4Y", ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (&Duration{Years: 4}); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseWithOptions() got = %v, want %v", got, want)
+	}
+
+	strict := ParseOptions{RequirePrefix: true}
+	if _, err := ParseWithOptions("4Y", strict); !errors.Is(err, ErrMissingPrefix) {
+		t.Errorf("ParseWithOptions() error = %v, want %v", err, ErrMissingPrefix)
+	}
+	if _, err := ParseWithOptions("-4Y", strict); !errors.Is(err, ErrMissingPrefix) {
+		t.Errorf("ParseWithOptions() error = %v, want %v", err, ErrMissingPrefix)
+	}
+	if _, err := ParseWithOptions("P4Y", strict); err != nil {
+		t.Errorf("ParseWithOptions() error = %v, want nil", err)
+	}
+}
+
+func TestParseWithOptions_DefaultTrailingUnit(t *testing.T) {
+	tests := []struct {
+		give    string
+		unit    string
+		want    *Duration
+		wantErr error
+	}{
+		{give: "PT1H30", unit: "seconds", want: &Duration{Hours: 1, Seconds: 30}},
+		{give: "PT1H30", unit: "minutes", want: &Duration{Hours: 1, Minutes: 30}},
+		{give: " -P1Y6 ", unit: "months", want: &Duration{Years: 1, Months: 6, Negative: true}},
+		{give: "1d12", unit: "hours", want: &Duration{Days: 1, Hours: 12}},
+		{give: "PT1H30", unit: "", wantErr: ErrUnexpectedInput},
+		{give: "P3", unit: "", wantErr: ErrUnexpectedInput},
+		{give: "PT1H30", unit: "fortnights", wantErr: ErrUnknownUnit},
+		{give: "PT1H30", unit: "months", wantErr: ErrUnexpectedInput},
+		{give: "P1D30", unit: "seconds", wantErr: ErrUnexpectedInput},
+		{give: "PT30S30", unit: "seconds", wantErr: ErrDuplicateUnit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give+"/"+tt.unit, func(t *testing.T) {
+			options := DefaultParseOptions()
+			options.DefaultTrailingUnit = tt.unit
+			got, err := ParseWithOptions(tt.give, options)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Errorf("ParseWithOptions() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			var parseErr *ParseError
+			if err != nil && (!errors.As(err, &parseErr) || parseErr.Input != tt.give) {
+				t.Errorf("ParseWithOptions() error = %v, want a *ParseError with the original input", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseWithOptions() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWithOptions_TrimChars(t *testing.T) {
+	options := DefaultParseOptions()
+	options.TrimChars = "\"'[]{} "
+	tests := []struct {
+		give string
+		want *Duration
+	}{
+		{give: "[P1D]", want: &Duration{Days: 1}},
+		{give: `"PT5M"`, want: &Duration{Minutes: 5}},
+		{give: "{ 'P1Y' }\n", want: &Duration{Years: 1}},
+		{give: "P1D", want: &Duration{Days: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			got, err := ParseWithOptions(tt.give, options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseWithOptions() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := Parse("[P1D]"); !errors.Is(err, ErrUnexpectedInput) {
+		t.Errorf("Parse() error = %v, want %v", err, ErrUnexpectedInput)
+	}
+
+	var parseErr *ParseError
+	if _, err := ParseWithOptions("[P1X]", options); !errors.As(err, &parseErr) || parseErr.Pos != 3 {
+		t.Errorf("ParseWithOptions() error = %v, want position 3", err)
+	}
+}
+
+func TestParseWithOptions_FractionalDaysAsTime(t *testing.T) {
+	options := ParseOptions{FractionalDaysAsTime: true}
+	tests := []struct {
+		give string
+		want *Duration
+	}{
+		{give: "P1.25D", want: &Duration{Days: 1, Hours: 6}},
+		{give: "P0.5DT1H", want: &Duration{Hours: 13}},
+		{give: "-P2.0625D", want: &Duration{Days: 2, Hours: 1, Minutes: 30, Negative: true}},
+		{give: "P1DT6H", want: &Duration{Days: 1, Hours: 6}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			got, err := ParseWithOptions(tt.give, options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseWithOptions() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	got, err := Parse("P1.25D")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Days != 1.25 {
+		t.Errorf("expected Parse to keep the fractional day, got %v", got)
+	}
+}
+
+func TestParseWithOptions_AllowRelative(t *testing.T) {
+	options := ParseOptions{AllowRelative: true}
+
+	got, err := ParseWithOptions("@P1D", options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Duration{Days: 1, RelativeToRef: true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseWithOptions() got = %v, want %v", got, want)
+	}
+
+	got, err = ParseWithOptions("@-PT5M", options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = &Duration{Minutes: 5, Negative: true, RelativeToRef: true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseWithOptions() got = %v, want %v", got, want)
+	}
+
+	got, err = ParseWithOptions("P1D", options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.RelativeToRef {
+		t.Error("expected RelativeToRef to be unset without a leading @")
+	}
+
+	if _, err := Parse("@P1D"); err == nil {
+		t.Error("expected error for @ without AllowRelative")
+	}
+
+	ref := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	relative, _ := ParseWithOptions("@P1D", options)
+	if end := relative.AddTo(ref); !end.Equal(ref.AddDate(0, 0, 1)) {
+		t.Errorf("AddTo() got = %v, want %v", end, ref.AddDate(0, 0, 1))
+	}
+
+	data, err := relative.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Duration
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&decoded, relative) {
+		t.Errorf("binary round-trip got = %v, want %v", &decoded, relative)
+	}
+
+	negative, _ := ParseWithOptions("@-PT5M", options)
+	if end := negative.AddTo(ref); !end.Equal(ref.Add(-5 * time.Minute)) {
+		t.Errorf("AddTo() got = %v, want %v", end, ref.Add(-5*time.Minute))
+	}
+	if negative.String() != "@-PT5M" {
+		t.Errorf("String() got = %s, want @-PT5M", negative)
+	}
+
+	jsonData, err := json.Marshal(negative)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var unmarshalled Duration
+	if err := json.Unmarshal(jsonData, &unmarshalled); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&unmarshalled, negative) {
+		t.Errorf("JSON round-trip got = %v, want %v", &unmarshalled, negative)
+	}
+
+	value, err := negative.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var scanned Duration
+	if err := scanned.Scan(value); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&scanned, negative) {
+		t.Errorf("Value/Scan round-trip got = %v, want %v", &scanned, negative)
+	}
+}
+
+func TestDuration_FractionalWeeks(t *testing.T) {
+	tests := []struct {
+		give  string
+		weeks float64
+	}{
+		{give: "P12.5W", weeks: 12.5},
+		{give: "12.5w", weeks: 12.5},
+		{give: "-P0.5W", weeks: 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			duration, err := Parse(tt.give)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if duration.Weeks != tt.weeks {
+				t.Errorf("Parse() got = %v weeks, want %v", duration.Weeks, tt.weeks)
+			}
+
+			reparsed, err := Parse(duration.String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(reparsed, duration) {
+				t.Errorf("round-trip got = %v, want %v", reparsed, duration)
+			}
+		})
+	}
+
+	duration, err := Parse("P12.5W")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if duration.String() != "P12.5W" {
+		t.Errorf("expected: %s, got: %s", "P12.5W", duration.String())
+	}
+	if want := time.Hour*24*7*12 + time.Hour*84; duration.ToTimeDuration() != want {
+		t.Errorf("ToTimeDuration() = %v, want %v", duration.ToTimeDuration(), want)
+	}
+
+	// FromTimeDuration only produces whole weeks, the half week comes back as days and hours
+	if got := FromTimeDuration((&Duration{Weeks: 1.5}).ToTimeDuration()).String(); got != "P1W3DT12H" {
+		t.Errorf("FromTimeDuration() got = %s, want %s", got, "P1W3DT12H")
+	}
+}
+
+func TestParse_DanglingTimeSeparator(t *testing.T) {
+	for _, s := range []string{"PT", "P1DT", "-P1YT"} {
+		t.Run(s, func(t *testing.T) {
+			if _, err := Parse(s); !errors.Is(err, ErrDanglingTimeSeparator) {
+				t.Errorf("Parse() error = %v, want %v", err, ErrDanglingTimeSeparator)
+			}
+			if IsValid(s) {
+				t.Error("IsValid() = true, want false")
+			}
+		})
+	}
+
+	if _, err := Parse("P1DT1S"); err != nil {
+		t.Errorf("did not expect error: %s", err.Error())
+	}
+}
+
+func TestParse_ParseError(t *testing.T) {
+	tests := []struct {
+		give    string
+		pos     int
+		wantErr error
+	}{
+		{give: "P1Dx", pos: 3, wantErr: ErrUnexpectedInput},
+		{give: "  -P1D2H", pos: 7, wantErr: ErrUnexpectedInput},
+		{give: "P1DT", pos: 3, wantErr: ErrDanglingTimeSeparator},
+		{give: "P1W2W", pos: 4, wantErr: ErrDuplicateUnit},
+		{give: "P1.2.3D", pos: 1, wantErr: strconv.ErrSyntax},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			_, err := Parse(tt.give)
+
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("Parse() error = %v, want a *ParseError", err)
+			}
+			if parseErr.Input != tt.give || parseErr.Pos != tt.pos {
+				t.Errorf("Parse() error input = %q, pos = %d, want %q, %d", parseErr.Input, parseErr.Pos, tt.give, tt.pos)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Parse() error = %v, want it to wrap %v", err, tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), strconv.Quote(tt.give)) {
+				t.Errorf("Parse() error = %v, want it to mention the input", err)
+			}
+		})
+	}
+
+	_, err := Parse("P1Dx")
+	if err.Error() != `parsing duration "P1Dx": unexpected input: 'x' at position 3` {
+		t.Errorf("Parse() error = %v", err)
+	}
+
+	_, err = Parse("P1D5H")
+	if err.Error() != `parsing duration "P1D5H": unexpected input: 'H' at position 4` {
+		t.Errorf("Parse() error = %v", err)
+	}
+}
+
+func TestParse_EmptyInput(t *testing.T) {
+	for _, s := range []string{"", " ", "-", "P", "-P"} {
+		t.Run(s, func(t *testing.T) {
+			if _, err := Parse(s); !errors.Is(err, ErrEmptyInput) {
+				t.Errorf("Parse() error = %v, want %v", err, ErrEmptyInput)
+			}
+		})
+	}
+
+	if _, err := Parse("PT"); !errors.Is(err, ErrDanglingTimeSeparator) {
+		t.Errorf("Parse() error = %v, want %v", err, ErrDanglingTimeSeparator)
+	}
+
+	var d Duration
+	if err := d.Scan(""); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("Scan() error = %v, want %v", err, ErrEmptyInput)
+	}
+}
+
+func TestParseISO(t *testing.T) {
+	tests := []struct {
+		give    string
+		want    *Duration
+		wantErr error
+	}{
+		{give: "P1DT6H", want: &Duration{Days: 1, Hours: 6}},
+		{give: "-P3Y", want: &Duration{Years: 3, Negative: true}},
+		{give: "P3Y", want: &Duration{Years: 3}},
+		{give: "P", wantErr: ErrEmptyInput},
+		{give: "PT", wantErr: ErrDanglingTimeSeparator},
+		{give: "3Y", wantErr: ErrMissingPrefix},
+		{give: "-3Y", wantErr: ErrMissingPrefix},
+		{give: "3Y6M4D12H30m5.5S", wantErr: ErrMissingPrefix},
+		{give: "P3Y6M4D12H", wantErr: ErrUnexpectedInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			got, err := ParseISO(tt.give)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Errorf("ParseISO() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseISO() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Parse keeps accepting the legacy format
+	if got, err := Parse("3Y"); err != nil || !reflect.DeepEqual(got, &Duration{Years: 3}) {
+		t.Errorf("Parse() got = %v, %v, want %v", got, err, &Duration{Years: 3})
+	}
+}
+
+func TestParseLenient(t *testing.T) {
+	tests := []struct {
+		give    string
+		want    *Duration
+		wantErr bool
+	}{
+		{give: "p1yt5m", want: &Duration{Years: 1, Minutes: 5}},
+		{give: "p2mt2m", want: &Duration{Months: 2, Minutes: 2}},
+		{give: "-pt1h30m", want: &Duration{Hours: 1, Minutes: 30, Negative: true}},
+		{give: "P1Dt6H", want: &Duration{Days: 1, Hours: 6}},
+		{give: "5m", want: &Duration{Months: 5}},
+		{give: "p1dx", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			got, err := ParseLenient(tt.give)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseLenient() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseLenient() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	_, err := ParseLenient("p1dx")
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.Input != "p1dx" {
+		t.Errorf("ParseLenient() error = %v, want a *ParseError with the original input", err)
+	}
+}
+
+func TestParseDurationOrTime(t *testing.T) {
+	tests := []struct {
+		give         string
+		wantDuration *Duration
+		wantTime     *time.Time
+		wantErr      bool
+	}{
+		{give: "P1D", wantDuration: &Duration{Days: 1}},
+		{give: "-PT30M", wantDuration: &Duration{Minutes: 30, Negative: true}},
+		{give: "2021-01-01T00:00:00Z", wantTime: func() *time.Time {
+			t := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+			return &t
+		}()},
+		{give: "yesterday", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			gotDuration, gotTime, err := ParseDurationOrTime(tt.give)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseDurationOrTime() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			var parseErr *ParseError
+			if tt.wantErr && !errors.As(err, &parseErr) {
+				t.Errorf("ParseDurationOrTime() error = %v, want a *ParseError", err)
+			}
+			if !reflect.DeepEqual(gotDuration, tt.wantDuration) {
+				t.Errorf("ParseDurationOrTime() duration = %v, want %v", gotDuration, tt.wantDuration)
+			}
+			if (gotTime == nil) != (tt.wantTime == nil) || gotTime != nil && !gotTime.Equal(*tt.wantTime) {
+				t.Errorf("ParseDurationOrTime() time = %v, want %v", gotTime, tt.wantTime)
+			}
+		})
+	}
+}
+
+// TestParse_TimeSeparator covers the ISO state machine: the T boundary, M meaning months before the T and
+// minutes after it, and weeks, which Parse accepts alongside other units (only Validate is strict about that)
+func TestParse_TimeSeparator(t *testing.T) {
+	tests := []struct {
+		give    string
+		want    *Duration
+		wantErr error
+	}{
+		{give: "P", wantErr: ErrEmptyInput},
+		{give: "PT", wantErr: ErrDanglingTimeSeparator},
+		{give: "P1DT", wantErr: ErrDanglingTimeSeparator},
+		{give: "P1DTT1H", wantErr: ErrUnexpectedInput},
+		{give: "T1H", wantErr: ErrUnexpectedInput},
+		{give: "PT1H", want: &Duration{Hours: 1}},
+		{give: "P1Y1M1W1DT1H1M1.5S", want: &Duration{Years: 1, Months: 1, Weeks: 1, Days: 1, Hours: 1, Minutes: 1, Seconds: 1.5}},
+		{give: "P1Dt1H", wantErr: ErrUnexpectedInput},
+		{give: "Pt1H", wantErr: ErrUnexpectedInput},
+		{give: "P1H", wantErr: ErrUnexpectedInput},
+		{give: "PT1D", wantErr: ErrUnexpectedInput},
+		{give: "PT1W", wantErr: ErrUnexpectedInput},
+		{give: "P1M", want: &Duration{Months: 1}},
+		{give: "PT1M", want: &Duration{Minutes: 1}},
+		{give: "P1MT1M", want: &Duration{Months: 1, Minutes: 1}},
+		{give: "P1MT1M1M", wantErr: ErrDuplicateUnit},
+		{give: "P1W", want: &Duration{Weeks: 1}},
+		{give: "P2W3D", want: &Duration{Weeks: 2, Days: 3}},
+		{give: "P1WT12H", want: &Duration{Weeks: 1, Hours: 12}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			got, err := Parse(tt.give)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Errorf("Parse() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_MonthsMinutes(t *testing.T) {
+	// ISO 8601 uses M for both, telling them apart by the T, while the legacy lowercase m is always minutes
+	tests := []struct {
+		give    string
+		want    *Duration
+		wantErr bool
+	}{
+		{give: "P1M", want: &Duration{Months: 1}},
+		{give: "PT1M", want: &Duration{Minutes: 1}},
+		{give: "P1MT1M", want: &Duration{Months: 1, Minutes: 1}},
+		{give: "PT5M", want: &Duration{Minutes: 5}},
+		{give: "PT5m", want: &Duration{Minutes: 5}},
+		{give: "P2MT3m", want: &Duration{Months: 2, Minutes: 3}},
+		{give: "P5m", wantErr: true},
+		{give: "5m", want: &Duration{Minutes: 5}},
+		{give: "1M5m", want: &Duration{Months: 1, Minutes: 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			got, err := Parse(tt.give)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_TrailingNumber(t *testing.T) {
+	for _, s := range []string{"5", "3Y6", "P3Y6", "PT1H30", "-P1.5"} {
+		t.Run(s, func(t *testing.T) {
+			_, err := Parse(s)
+			if !errors.Is(err, ErrUnexpectedInput) || !strings.Contains(err.Error(), "trailing number without a unit") {
+				t.Errorf("Parse() error = %v, want a trailing number error", err)
+			}
+		})
+	}
+
+	for _, s := range []string{"5S", "3Y6M", "P3Y6M", "PT1H30M", "-P1.5D"} {
+		t.Run(s, func(t *testing.T) {
+			if _, err := Parse(s); err != nil {
+				t.Errorf("did not expect error: %s", err.Error())
+			}
+		})
+	}
 }
@@ -0,0 +1,39 @@
+package duration
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ErrEnvUnset is returned by FromEnv when the environment variable is unset or empty
+var ErrEnvUnset = errors.New("environment variable is unset")
+
+// FromEnv parses the duration held by the environment variable key, surrounding whitespace is trimmed.
+// ErrEnvUnset is returned when the variable is unset or empty, any other error means it holds an invalid duration.
+func FromEnv(key string) (*Duration, error) {
+	value, ok := os.LookupEnv(key)
+	value = strings.TrimSpace(value)
+	if !ok || value == "" {
+		return nil, fmt.Errorf("%s: %w", key, ErrEnvUnset)
+	}
+
+	duration, err := Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid duration in %s: %w", key, err)
+	}
+
+	return duration, nil
+}
+
+// FromEnvDefault is like FromEnv but returns def when the environment variable is unset or empty,
+// an invalid duration is still returned as an error rather than silently falling back.
+func FromEnvDefault(key string, def *Duration) (*Duration, error) {
+	duration, err := FromEnv(key)
+	if errors.Is(err, ErrEnvUnset) {
+		return def, nil
+	}
+
+	return duration, err
+}
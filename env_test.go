@@ -0,0 +1,61 @@
+package duration
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("DURATION_SET", " PT5M\n")
+	t.Setenv("DURATION_EMPTY", "")
+	t.Setenv("DURATION_INVALID", "five minutes")
+
+	got, err := FromEnv("DURATION_SET")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, &Duration{Minutes: 5}) {
+		t.Errorf("FromEnv() got = %v, want %v", got, &Duration{Minutes: 5})
+	}
+
+	for _, key := range []string{"DURATION_UNSET", "DURATION_EMPTY"} {
+		if _, err := FromEnv(key); !errors.Is(err, ErrEnvUnset) {
+			t.Errorf("FromEnv(%s) error = %v, want %v", key, err, ErrEnvUnset)
+		}
+	}
+
+	_, err = FromEnv("DURATION_INVALID")
+	if err == nil || errors.Is(err, ErrEnvUnset) {
+		t.Errorf("FromEnv() error = %v, want a parse error", err)
+	}
+	if !errors.Is(err, ErrUnexpectedInput) {
+		t.Errorf("FromEnv() error = %v, want it to wrap %v", err, ErrUnexpectedInput)
+	}
+}
+
+func TestFromEnvDefault(t *testing.T) {
+	t.Setenv("DURATION_SET", "PT5M")
+	t.Setenv("DURATION_INVALID", "five minutes")
+	def := &Duration{Hours: 1}
+
+	got, err := FromEnvDefault("DURATION_SET", def)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, &Duration{Minutes: 5}) {
+		t.Errorf("FromEnvDefault() got = %v, want %v", got, &Duration{Minutes: 5})
+	}
+
+	got, err = FromEnvDefault("DURATION_UNSET", def)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != def {
+		t.Errorf("FromEnvDefault() got = %v, want %v", got, def)
+	}
+
+	if _, err := FromEnvDefault("DURATION_INVALID", def); err == nil {
+		t.Error("expected error for invalid duration")
+	}
+}
@@ -0,0 +1,361 @@
+package duration
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CountdownString formats the total time of the *Duration as a countdown, MM:SS when it's under an hour
+// (e.g. 01:30) and HH:MM:SS otherwise (e.g. 01:00:05). Fractional seconds are truncated.
+func (duration *Duration) CountdownString() string {
+	total := duration.ToTimeDuration()
+	sign := ""
+	if total < 0 {
+		sign = "-"
+		total = -total
+	}
+
+	hours := total / time.Hour
+	minutes := total % time.Hour / time.Minute
+	seconds := total % time.Minute / time.Second
+
+	if hours > 0 {
+		return fmt.Sprintf("%s%02d:%02d:%02d", sign, hours, minutes, seconds)
+	}
+
+	return fmt.Sprintf("%s%02d:%02d", sign, minutes, seconds)
+}
+
+// Compact returns the *Duration as a compact lowercase token such as 1d6h30m or 1y2mo, as used by dashboards
+// and Prometheus-adjacent tooling. Weeks are folded into days and months use "mo" to tell them apart from minutes.
+func (duration *Duration) Compact() string {
+	d := ""
+
+	appendD := func(designator string, value float64) {
+		if value != 0 {
+			d += formatFloat(value) + designator
+		}
+	}
+
+	appendD("y", duration.Years)
+	appendD("mo", duration.Months)
+	appendD("d", duration.Weeks*7+duration.Days)
+	appendD("h", duration.Hours)
+	appendD("m", duration.Minutes)
+	appendD("s", duration.Seconds)
+
+	if d == "" {
+		d = "0s"
+	}
+
+	if duration.Negative {
+		return "-" + d
+	}
+
+	return d
+}
+
+// etaUnits are the granularities FormatETA picks from, each is used while the rounded count stays below limit
+var etaUnits = []struct {
+	unit          string
+	length, limit time.Duration
+}{
+	{"second", time.Second, time.Minute},
+	{"minute", time.Minute, time.Hour},
+	{"hour", time.Hour, nsPerDay},
+	{"day", nsPerDay, 0},
+}
+
+// FormatETA formats remaining as an approximate time left for download or progress UIs, picking the granularity
+// automatically: "less than a second", then "about 40 seconds", "about 3 minutes", "about 5 hours" or "about 2 days".
+// The count is rounded and moves up to the next unit once it would reach it, so 59m40s is "about 1 hour".
+func FormatETA(remaining time.Duration) string {
+	if remaining < time.Second {
+		return "less than a second"
+	}
+
+	for _, eta := range etaUnits {
+		count := math.Round(float64(remaining) / float64(eta.length))
+		if eta.limit == 0 || time.Duration(count)*eta.length < eta.limit {
+			return "about " + English.Unit(eta.unit, count)
+		}
+	}
+
+	return ""
+}
+
+// CanonicalString returns the ISO 8601 duration string for the *Duration with fractions folded down into the next
+// smaller unit first, so only the smallest unit is fractional (e.g. P1.5D becomes P1DT12H) as strict parsers expect.
+// Fractions of a month are folded into days and hours using the same fuzzy month length as ToTimeDuration
+// (730 hours) and seconds are rounded to the nanosecond.
+func (duration *Duration) CanonicalString() string {
+	return duration.folded().String()
+}
+
+// folded returns a copy of the *Duration with the fractions of each unit folded down into the next smaller one,
+// see CanonicalString
+func (duration *Duration) folded() *Duration {
+	folded := *duration
+
+	fold := func(from, to *float64, factor float64) {
+		whole, fraction := math.Modf(*from)
+		if fraction != 0 {
+			*from = whole
+			// rounding to nine decimals keeps noise like 0.4*60 = 23.999999999999996 from cascading further down
+			*to += math.Round(fraction*factor*1e9) / 1e9
+		}
+	}
+
+	fold(&folded.Years, &folded.Months, 12)
+	// a fraction of a month is a fuzzy 730 hours, it's split into days and hours directly since a month isn't a
+	// whole number of days and folding through a fractional day count would leave noise in the seconds
+	if whole, fraction := math.Modf(folded.Months); fraction != 0 {
+		folded.Months = whole
+		hours := math.Round(fraction*hoursPerMonth*1e9) / 1e9
+		days := math.Floor(hours / hoursPerDay)
+		folded.Days += days
+		folded.Hours += hours - days*hoursPerDay
+	}
+	fold(&folded.Weeks, &folded.Days, 7)
+	fold(&folded.Days, &folded.Hours, hoursPerDay)
+	fold(&folded.Hours, &folded.Minutes, 60)
+	fold(&folded.Minutes, &folded.Seconds, 60)
+	folded.Seconds = math.Round(folded.Seconds*nsPerSecond) / nsPerSecond
+
+	return &folded
+}
+
+// HumanString returns the *Duration spelled out in English for display, e.g. "1 year, 2 months, 1.5 hours",
+// negative durations are prefixed with a minus sign and a zero duration is "0 seconds".
+func (duration *Duration) HumanString() string {
+	return duration.LocalizedString(English)
+}
+
+// Localizer provides the words LocalizedString spells a duration out with, so other languages can be plugged in
+// without this package depending on an i18n library.
+type Localizer interface {
+	// Unit returns value followed by the name of unit in the right plural form, e.g. "1 year" or "1.5 hours",
+	// unit is one of year, month, week, day, hour, minute or second
+	Unit(unit string, value float64) string
+	// Join combines the spelled out units into a single string, e.g. "1 year, 2 months"
+	Join(units []string) string
+}
+
+// English is the Localizer used by HumanString
+var English Localizer = english{}
+
+type english struct{}
+
+func (english) Unit(unit string, value float64) string {
+	if value != 1 {
+		unit += "s"
+	}
+	return formatFloat(value) + " " + unit
+}
+
+func (english) Join(units []string) string {
+	return strings.Join(units, ", ")
+}
+
+// LocalizedString returns the *Duration spelled out with the words of locale, e.g. "1 year, 2 months, 1.5 hours"
+// for English. Negative durations are prefixed with a minus sign and a zero duration is spelled as 0 seconds.
+func (duration *Duration) LocalizedString(locale Localizer) string {
+	var units []string
+
+	appendD := func(unit string, value float64) {
+		if value != 0 {
+			units = append(units, locale.Unit(unit, value))
+		}
+	}
+
+	appendD("year", duration.Years)
+	appendD("month", duration.Months)
+	appendD("week", duration.Weeks)
+	appendD("day", duration.Days)
+	appendD("hour", duration.Hours)
+	appendD("minute", duration.Minutes)
+	appendD("second", duration.Seconds)
+
+	if len(units) == 0 {
+		return locale.Unit("second", 0)
+	}
+
+	d := locale.Join(units)
+	if duration.Negative {
+		return "-" + d
+	}
+
+	return d
+}
+
+// StringAnnotated returns the canonical ISO 8601 string followed by the HumanString in parentheses,
+// e.g. "P1Y (1 year)", for debugging output and admin UIs.
+func (duration *Duration) StringAnnotated() string {
+	return duration.CanonicalString() + " (" + duration.HumanString() + ")"
+}
+
+// FileSafeString returns the ISO 8601 string for the *Duration as a token that's safe in filenames on every OS,
+// e.g. P1DT6H for naming backup files by retention. Negative durations start with "neg" instead of a minus sign
+// since command line tools mistake a leading minus for a flag, e.g. negPT5M, and RelativeToRef durations start
+// with "rel" instead of an @, e.g. relnegPT5M.
+func (duration *Duration) FileSafeString() string {
+	positive := *duration
+	positive.Negative = false
+	positive.RelativeToRef = false
+
+	d := strings.Map(func(r rune) rune {
+		if r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '.' {
+			return r
+		}
+		return '_'
+	}, positive.String())
+
+	if duration.Negative {
+		d = "neg" + d
+	}
+	if duration.RelativeToRef {
+		d = "rel" + d
+	}
+
+	return d
+}
+
+// CSVHeader returns the column names matching CSVFields
+func CSVHeader() []string {
+	return []string{"years", "months", "weeks", "days", "hours", "minutes", "seconds", "negative"}
+}
+
+// CSVFields returns every unit of the *Duration followed by its sign as strings in the fixed column order of
+// CSVHeader, zero units included, so rows line up in CSV reports, e.g. 0,0,0,1,6,0,0,false for P1DT6H.
+func (duration *Duration) CSVFields() []string {
+	return []string{
+		formatFloat(duration.Years),
+		formatFloat(duration.Months),
+		formatFloat(duration.Weeks),
+		formatFloat(duration.Days),
+		formatFloat(duration.Hours),
+		formatFloat(duration.Minutes),
+		formatFloat(duration.Seconds),
+		strconv.FormatBool(duration.Negative),
+	}
+}
+
+// FormatCapped returns the *Duration as a compact token like Compact but never with a unit larger than maxUnit,
+// the larger units are folded into it, e.g. P1DT2H capped at "hours" is 26h. The units are the same as for
+// WholeUnits, an unknown one leaves the output uncapped, and weeks are kept as w when they're allowed. Folding
+// years or months uses the same fuzzy lengths as ToTimeDuration.
+func (duration *Duration) FormatCapped(maxUnit string) string {
+	capLength, ok := wholeUnitLengths[maxUnit]
+	if !ok {
+		maxUnit, capLength = "years", nsPerYear
+	}
+	units := []struct {
+		name, designator string
+		value            float64
+	}{
+		{"years", "y", duration.Years},
+		{"months", "mo", duration.Months},
+		{"weeks", "w", duration.Weeks},
+		{"days", "d", duration.Days},
+		{"hours", "h", duration.Hours},
+		{"minutes", "m", duration.Minutes},
+		{"seconds", "s", duration.Seconds},
+	}
+
+	d := ""
+	folded := 0.0
+	for _, unit := range units {
+		length := wholeUnitLengths[unit.name]
+		if length > capLength {
+			folded += unit.value * float64(length) / float64(capLength)
+			continue
+		}
+
+		value := unit.value
+		if unit.name == maxUnit {
+			value += folded
+		}
+		if value != 0 {
+			d += formatFloat(value) + unit.designator
+		}
+	}
+
+	if d == "" {
+		d = "0s"
+	}
+
+	if duration.Negative {
+		return "-" + d
+	}
+
+	return d
+}
+
+// JavaStrings splits the *Duration into the two ISO 8601 strings Java's java.time expects, the calendar part for
+// Period (PnYnMnD, weeks folded into days, P0D when empty) and the clock part for Duration (PTnHnMnS, PT0S when
+// empty), e.g. P1Y2DT3H gives P1Y2D and PT3H. Negative durations put a minus in front of both, which both parse.
+// Period only takes whole numbers and Duration only a fractional second, so fractions are folded down first like
+// CanonicalString does, a fractional day moving into the clock part, e.g. P1.5D gives P1D and PT12H.
+func (duration *Duration) JavaStrings() (period string, time string) {
+	folded := duration.folded()
+	calendar := &Duration{Years: folded.Years, Months: folded.Months, Days: folded.Weeks*7 + folded.Days}
+	clock := &Duration{Hours: folded.Hours, Minutes: folded.Minutes, Seconds: folded.Seconds}
+
+	period, time = "P0D", clock.String()
+	if !calendar.IsZero() {
+		period = calendar.String()
+	}
+
+	if duration.Negative {
+		return "-" + period, "-" + time
+	}
+
+	return period, time
+}
+
+// NegativeStyle controls how StringWithOptions marks a negative duration
+type NegativeStyle int
+
+const (
+	// NegativeMinus prefixes negative durations with a minus sign, e.g. -P1D, same as String
+	NegativeMinus NegativeStyle = iota
+	// NegativeParentheses wraps negative durations in parentheses accounting-style, e.g. (P1D)
+	NegativeParentheses
+	// NegativeAgo suffixes negative durations with " ago", e.g. P1D ago
+	NegativeAgo
+)
+
+// FormatOptions tweak how StringWithOptions renders a duration, the zero value behaves like String
+type FormatOptions struct {
+	NegativeStyle NegativeStyle
+}
+
+// StringWithOptions is like String but lets the caller tweak the output with options, the @ of a RelativeToRef
+// duration always comes first, e.g. @(P1D)
+func (duration *Duration) StringWithOptions(options FormatOptions) string {
+	if !duration.Negative {
+		return duration.String()
+	}
+
+	positive := *duration
+	positive.Negative = false
+	positive.RelativeToRef = false
+	d := positive.String()
+
+	switch options.NegativeStyle {
+	case NegativeParentheses:
+		d = "(" + d + ")"
+	case NegativeAgo:
+		d += " ago"
+	default:
+		d = "-" + d
+	}
+	if duration.RelativeToRef {
+		d = "@" + d
+	}
+
+	return d
+}
@@ -0,0 +1,316 @@
+package duration
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDuration_CountdownString(t *testing.T) {
+	tests := []struct {
+		give *Duration
+		want string
+	}{
+		{give: &Duration{}, want: "00:00"},
+		{give: &Duration{Seconds: 90}, want: "01:30"},
+		{give: &Duration{Minutes: 59, Seconds: 59.9}, want: "59:59"},
+		{give: &Duration{Hours: 1, Seconds: 5}, want: "01:00:05"},
+		{give: &Duration{Days: 1, Minutes: 2}, want: "24:02:00"},
+		{give: &Duration{Seconds: 5, Negative: true}, want: "-00:05"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.give.CountdownString(); got != tt.want {
+				t.Errorf("CountdownString() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_Compact(t *testing.T) {
+	tests := []struct {
+		give *Duration
+		want string
+	}{
+		{give: &Duration{}, want: "0s"},
+		{give: &Duration{Days: 1, Hours: 6, Minutes: 30}, want: "1d6h30m"},
+		{give: &Duration{Years: 1, Months: 2}, want: "1y2mo"},
+		{give: &Duration{Weeks: 1, Days: 2}, want: "9d"},
+		{give: &Duration{Seconds: 1.5, Negative: true}, want: "-1.5s"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.give.Compact(); got != tt.want {
+				t.Errorf("Compact() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatETA(t *testing.T) {
+	tests := []struct {
+		give time.Duration
+		want string
+	}{
+		{give: -time.Second, want: "less than a second"},
+		{give: 500 * time.Millisecond, want: "less than a second"},
+		{give: time.Second, want: "about 1 second"},
+		{give: 40 * time.Second, want: "about 40 seconds"},
+		{give: 59*time.Second + 600*time.Millisecond, want: "about 1 minute"},
+		{give: 3*time.Minute + 10*time.Second, want: "about 3 minutes"},
+		{give: 59*time.Minute + 40*time.Second, want: "about 1 hour"},
+		{give: 5*time.Hour + 20*time.Minute, want: "about 5 hours"},
+		{give: 23*time.Hour + 45*time.Minute, want: "about 1 day"},
+		{give: 50 * time.Hour, want: "about 2 days"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give.String(), func(t *testing.T) {
+			if got := FormatETA(tt.give); got != tt.want {
+				t.Errorf("FormatETA() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_CanonicalString(t *testing.T) {
+	tests := []struct {
+		give *Duration
+		want string
+	}{
+		{give: &Duration{Days: 1.5}, want: "P1DT12H"},
+		{give: &Duration{Years: 1.5}, want: "P1Y6M"},
+		{give: &Duration{Months: 1.5}, want: "P1M15DT5H"},
+		{give: &Duration{Years: 1.25, Months: 0.25}, want: "P1Y3M7DT14H30M"},
+		{give: &Duration{Weeks: 1.5, Hours: 1}, want: "P1W3DT13H"},
+		{give: &Duration{Hours: 2.4}, want: "PT2H24M"},
+		{give: &Duration{Minutes: 1.25, Seconds: 0.5}, want: "PT1M15.5S"},
+		{give: &Duration{Seconds: 1.5, Negative: true}, want: "-PT1.5S"},
+		{give: &Duration{}, want: "PT0S"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.give.CanonicalString(); got != tt.want {
+				t.Errorf("CanonicalString() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_HumanString(t *testing.T) {
+	tests := []struct {
+		give *Duration
+		want string
+	}{
+		{give: &Duration{}, want: "0 seconds"},
+		{give: &Duration{Years: 1}, want: "1 year"},
+		{give: &Duration{Years: 1, Months: 2, Hours: 1.5}, want: "1 year, 2 months, 1.5 hours"},
+		{give: &Duration{Weeks: 2, Days: 1, Minutes: 1, Seconds: 30}, want: "2 weeks, 1 day, 1 minute, 30 seconds"},
+		{give: &Duration{Days: 3, Negative: true}, want: "-3 days"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.give.HumanString(); got != tt.want {
+				t.Errorf("HumanString() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// german is a fake Localizer for testing LocalizedString with non-English words
+type german struct{}
+
+func (german) Unit(unit string, value float64) string {
+	names := map[string][2]string{
+		"year": {"Jahr", "Jahre"}, "month": {"Monat", "Monate"}, "week": {"Woche", "Wochen"},
+		"day": {"Tag", "Tage"}, "hour": {"Stunde", "Stunden"}, "minute": {"Minute", "Minuten"},
+		"second": {"Sekunde", "Sekunden"},
+	}
+	name := names[unit][1]
+	if value == 1 {
+		name = names[unit][0]
+	}
+	return strings.Replace(formatFloat(value), ".", ",", 1) + " " + name
+}
+
+func (german) Join(units []string) string {
+	if len(units) == 1 {
+		return units[0]
+	}
+	return strings.Join(units[:len(units)-1], ", ") + " und " + units[len(units)-1]
+}
+
+func TestDuration_LocalizedString(t *testing.T) {
+	tests := []struct {
+		give   *Duration
+		locale Localizer
+		want   string
+	}{
+		{give: &Duration{Years: 1, Months: 2, Hours: 1.5}, locale: English, want: "1 year, 2 months, 1.5 hours"},
+		{give: &Duration{}, locale: german{}, want: "0 Sekunden"},
+		{give: &Duration{Days: 1}, locale: german{}, want: "1 Tag"},
+		{give: &Duration{Years: 1, Months: 2, Hours: 1.5}, locale: german{}, want: "1 Jahr, 2 Monate und 1,5 Stunden"},
+		{give: &Duration{Weeks: 2, Negative: true}, locale: german{}, want: "-2 Wochen"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.give.LocalizedString(tt.locale); got != tt.want {
+				t.Errorf("LocalizedString() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_StringAnnotated(t *testing.T) {
+	tests := []struct {
+		give *Duration
+		want string
+	}{
+		{give: &Duration{Years: 1}, want: "P1Y (1 year)"},
+		{give: &Duration{Days: 1, Hours: 6}, want: "P1DT6H (1 day, 6 hours)"},
+		{give: &Duration{Minutes: 5, Negative: true}, want: "-PT5M (-5 minutes)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.give.StringAnnotated(); got != tt.want {
+				t.Errorf("StringAnnotated() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_FileSafeString(t *testing.T) {
+	tests := []struct {
+		give *Duration
+		want string
+	}{
+		{give: &Duration{Days: 1, Hours: 6}, want: "P1DT6H"},
+		{give: &Duration{Seconds: 1.5}, want: "PT1.5S"},
+		{give: &Duration{Minutes: 5, Negative: true}, want: "negPT5M"},
+		{give: &Duration{Days: 1, RelativeToRef: true}, want: "relP1D"},
+		{give: &Duration{Minutes: 5, Negative: true, RelativeToRef: true}, want: "relnegPT5M"},
+		{give: &Duration{}, want: "PT0S"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got := tt.give.FileSafeString()
+			if got != tt.want {
+				t.Errorf("FileSafeString() got = %s, want %s", got, tt.want)
+			}
+			if strings.ContainsAny(got, `:/\*?"<>| `) || strings.HasPrefix(got, "-") {
+				t.Errorf("FileSafeString() got = %s, which isn't safe in filenames", got)
+			}
+		})
+	}
+}
+
+func TestDuration_CSVFields(t *testing.T) {
+	tests := []struct {
+		give *Duration
+		want []string
+	}{
+		{give: &Duration{Days: 1, Hours: 6}, want: []string{"0", "0", "0", "1", "6", "0", "0", "false"}},
+		{give: &Duration{Years: 1, Months: 2, Weeks: 3, Seconds: 1.5, Negative: true}, want: []string{"1", "2", "3", "0", "0", "0", "1.5", "true"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give.String(), func(t *testing.T) {
+			got := tt.give.CSVFields()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CSVFields() got = %q, want %q", got, tt.want)
+			}
+			if len(got) != len(CSVHeader()) {
+				t.Errorf("CSVFields() has %d columns, CSVHeader() has %d", len(got), len(CSVHeader()))
+			}
+		})
+	}
+}
+
+func TestDuration_FormatCapped(t *testing.T) {
+	tests := []struct {
+		give    *Duration
+		maxUnit string
+		want    string
+	}{
+		{give: &Duration{Days: 1, Hours: 2}, maxUnit: "hours", want: "26h"},
+		{give: &Duration{Weeks: 1, Days: 1, Minutes: 30}, maxUnit: "hours", want: "192h30m"},
+		{give: &Duration{Days: 1, Hours: 2}, maxUnit: "minutes", want: "1560m"},
+		{give: &Duration{Weeks: 2, Days: 1}, maxUnit: "weeks", want: "2w1d"},
+		{give: &Duration{Years: 1, Hours: 2}, maxUnit: "days", want: "365d2h"},
+		{give: &Duration{Days: 1, Hours: 2}, maxUnit: "years", want: "1d2h"},
+		{give: &Duration{Hours: 3, Negative: true}, maxUnit: "minutes", want: "-180m"},
+		{give: &Duration{}, maxUnit: "hours", want: "0s"},
+		{give: &Duration{Weeks: 1, Days: 1, Hours: 2}, maxUnit: "fortnights", want: "1w1d2h"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.maxUnit+"/"+tt.want, func(t *testing.T) {
+			if got := tt.give.FormatCapped(tt.maxUnit); got != tt.want {
+				t.Errorf("FormatCapped() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_JavaStrings(t *testing.T) {
+	tests := []struct {
+		give         string
+		period, time string
+	}{
+		{give: "P1Y2DT3H", period: "P1Y2D", time: "PT3H"},
+		{give: "P1W2D", period: "P9D", time: "PT0S"},
+		{give: "PT1H30M", period: "P0D", time: "PT1H30M"},
+		{give: "-P1MT5S", period: "-P1M", time: "-PT5S"},
+		{give: "PT0S", period: "P0D", time: "PT0S"},
+		{give: "P1.5DT1.5H", period: "P1D", time: "PT13H30M"},
+		{give: "P1.5W", period: "P10D", time: "PT12H"},
+		{give: "P1.5M", period: "P1M15D", time: "PT5H"},
+		{give: "PT1.5M", period: "P0D", time: "PT1M30S"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			d, err := Parse(tt.give)
+			if err != nil {
+				t.Fatal(err)
+			}
+			period, clock := d.JavaStrings()
+			if period != tt.period || clock != tt.time {
+				t.Errorf("JavaStrings() got = %s, %s, want %s, %s", period, clock, tt.period, tt.time)
+			}
+		})
+	}
+}
+
+func TestDuration_StringWithOptions(t *testing.T) {
+	negative := &Duration{Days: 1, Negative: true}
+	positive := &Duration{Days: 1}
+	relative := &Duration{Days: 1, Negative: true, RelativeToRef: true}
+	tests := []struct {
+		name    string
+		give    *Duration
+		options FormatOptions
+		want    string
+	}{
+		{name: "default", give: negative, options: FormatOptions{}, want: "-P1D"},
+		{name: "parentheses", give: negative, options: FormatOptions{NegativeStyle: NegativeParentheses}, want: "(P1D)"},
+		{name: "ago", give: negative, options: FormatOptions{NegativeStyle: NegativeAgo}, want: "P1D ago"},
+		{name: "positive-parentheses", give: positive, options: FormatOptions{NegativeStyle: NegativeParentheses}, want: "P1D"},
+		{name: "positive-ago", give: positive, options: FormatOptions{NegativeStyle: NegativeAgo}, want: "P1D"},
+		{name: "relative-default", give: relative, options: FormatOptions{}, want: "@-P1D"},
+		{name: "relative-parentheses", give: relative, options: FormatOptions{NegativeStyle: NegativeParentheses}, want: "@(P1D)"},
+		{name: "relative-ago", give: relative, options: FormatOptions{NegativeStyle: NegativeAgo}, want: "@P1D ago"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.give.StringWithOptions(tt.options); got != tt.want {
+				t.Errorf("StringWithOptions() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if got, want := relative.StringWithOptions(FormatOptions{}), relative.String(); got != want {
+		t.Errorf("StringWithOptions() got = %s, want the same as String %s", got, want)
+	}
+	parsed, err := ParseWithOptions(relative.String(), ParseOptions{AllowRelative: true})
+	if err != nil || !reflect.DeepEqual(parsed, relative) {
+		t.Errorf("ParseWithOptions() got = %v, %v, want %v", parsed, err, relative)
+	}
+}
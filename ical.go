@@ -0,0 +1,151 @@
+package duration
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidICal is returned by ParseICal when the input doesn't follow the RFC 5545 DURATION grammar
+	ErrInvalidICal = errors.New("invalid iCalendar duration")
+	// ErrNotICal is returned by ICalString when the *Duration can't be written as an RFC 5545 DURATION
+	ErrNotICal = errors.New("duration not representable in iCalendar")
+)
+
+// icalForms are the unit sequences the RFC 5545 grammar allows: weeks alone, or days and/or
+// a time part where hours, minutes and seconds can't skip a unit in between.
+var icalForms = map[string]bool{
+	"W": true, "D": true,
+	"DTH": true, "DTHM": true, "DTHMS": true, "DTM": true, "DTMS": true, "DTS": true,
+	"TH": true, "THM": true, "THMS": true, "TM": true, "TMS": true, "TS": true,
+}
+
+// ParseICal parses an RFC 5545 (iCalendar) DURATION value such as P1W, +P1DT2H or -PT30M into a *Duration.
+// The grammar is stricter than Parse: only whole numbers, no years or months, weeks can't be combined with
+// other units and the units must be in order.
+func ParseICal(s string) (*Duration, error) {
+	duration := &Duration{}
+	d := s
+
+	switch {
+	case strings.HasPrefix(d, "-"):
+		duration.Negative = true
+		d = d[1:]
+	case strings.HasPrefix(d, "+"):
+		d = d[1:]
+	}
+
+	if !strings.HasPrefix(d, "P") {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidICal, s)
+	}
+	d = d[1:]
+
+	form := ""
+	for d != "" {
+		if d[0] == 'T' {
+			form += "T"
+			d = d[1:]
+			continue
+		}
+
+		n := 0
+		for n < len(d) && d[n] >= '0' && d[n] <= '9' {
+			n++
+		}
+		if n == 0 || n == len(d) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidICal, s)
+		}
+
+		value, err := strconv.ParseFloat(d[:n], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidICal, s)
+		}
+
+		switch d[n] {
+		case 'W':
+			duration.Weeks = value
+		case 'D':
+			duration.Days = value
+		case 'H':
+			duration.Hours = value
+		case 'M':
+			duration.Minutes = value
+		case 'S':
+			duration.Seconds = value
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrInvalidICal, s)
+		}
+		form += d[n : n+1]
+		d = d[n+1:]
+	}
+
+	if !icalForms[form] {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidICal, s)
+	}
+
+	return duration, nil
+}
+
+// ICalString returns the RFC 5545 (iCalendar) DURATION value for the *Duration, e.g. P1W or -PT30M.
+// Weeks are only written on their own, otherwise they're folded into days, and the hours, minutes and seconds
+// are carried into each other but never into days since iCalendar days are nominal calendar days.
+// An error is returned for years, months, fractional days or fractional seconds which iCalendar can't express.
+func (duration *Duration) ICalString() (string, error) {
+	if duration.Years != 0 || duration.Months != 0 {
+		return "", fmt.Errorf("%w: %s has years or months", ErrNotICal, duration)
+	}
+
+	days := duration.Weeks*7 + duration.Days
+	seconds := duration.Hours*3600 + duration.Minutes*60 + duration.Seconds
+	if days != math.Trunc(days) || seconds != math.Trunc(seconds) {
+		return "", fmt.Errorf("%w: %s has fractional days or seconds", ErrNotICal, duration)
+	}
+
+	d := "P"
+	switch {
+	case days == 0 && seconds == 0:
+		d += "T0S"
+	case duration.Days == 0 && seconds == 0:
+		d += formatFloat(duration.Weeks) + "W"
+	default:
+		if days != 0 {
+			d += formatFloat(days) + "D"
+		}
+		if seconds != 0 {
+			d += "T" + icalTime(int64(seconds))
+		}
+	}
+
+	if duration.Negative {
+		return "-" + d, nil
+	}
+
+	return d, nil
+}
+
+// icalTime writes seconds as hours, minutes and seconds, including a zero unit when it sits between two
+// non-zero ones since the RFC 5545 grammar doesn't allow skipping, e.g. 1H0M5S
+func icalTime(seconds int64) string {
+	values := []int64{seconds / 3600, seconds % 3600 / 60, seconds % 60}
+	designators := "HMS"
+
+	first, last := -1, -1
+	for i, value := range values {
+		if value != 0 {
+			if first == -1 {
+				first = i
+			}
+			last = i
+		}
+	}
+
+	d := ""
+	for i := first; i <= last; i++ {
+		d += strconv.FormatInt(values[i], 10) + designators[i:i+1]
+	}
+
+	return d
+}
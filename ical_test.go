@@ -0,0 +1,81 @@
+package duration
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseICal(t *testing.T) {
+	tests := []struct {
+		give    string
+		want    *Duration
+		wantErr bool
+	}{
+		{give: "P1W", want: &Duration{Weeks: 1}},
+		{give: "-PT30M", want: &Duration{Minutes: 30, Negative: true}},
+		{give: "+P1DT2H", want: &Duration{Days: 1, Hours: 2}},
+		{give: "P15DT5H0M20S", want: &Duration{Days: 15, Hours: 5, Seconds: 20}},
+		{give: "PT45S", want: &Duration{Seconds: 45}},
+		{give: "P1Y", wantErr: true},
+		{give: "P1M", wantErr: true},
+		{give: "P1W2D", wantErr: true},
+		{give: "PT1H5S", wantErr: true},
+		{give: "PT1.5H", wantErr: true},
+		{give: "P2H", wantErr: true},
+		{give: "PT", wantErr: true},
+		{give: "P", wantErr: true},
+		{give: "1D", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			got, err := ParseICal(tt.give)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseICal() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && !errors.Is(err, ErrInvalidICal) {
+				t.Errorf("ParseICal() error = %v, want %v", err, ErrInvalidICal)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseICal() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_ICalString(t *testing.T) {
+	tests := []struct {
+		give    *Duration
+		want    string
+		wantErr bool
+	}{
+		{give: &Duration{Weeks: 1}, want: "P1W"},
+		{give: &Duration{Minutes: 30, Negative: true}, want: "-PT30M"},
+		{give: &Duration{Weeks: 1, Days: 1, Hours: 2}, want: "P8DT2H"},
+		{give: &Duration{Minutes: 90}, want: "PT1H30M"},
+		{give: &Duration{Hours: 1, Seconds: 5}, want: "PT1H0M5S"},
+		{give: &Duration{Hours: 36}, want: "PT36H"},
+		{give: &Duration{}, want: "PT0S"},
+		{give: &Duration{Years: 1}, wantErr: true},
+		{give: &Duration{Seconds: 0.5}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give.String(), func(t *testing.T) {
+			got, err := tt.give.ICalString()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ICalString() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ICalString() got = %s, want %s", got, tt.want)
+			}
+			if tt.wantErr {
+				return
+			}
+			if _, err := ParseICal(got); err != nil {
+				t.Errorf("ParseICal(ICalString()) error = %v", err)
+			}
+		})
+	}
+}
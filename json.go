@@ -0,0 +1,62 @@
+package duration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// OmitZeroDuration wraps a Duration for APIs where a zero duration should be sent as JSON null
+// rather than "PT0S", it marshals and unmarshals like Duration otherwise.
+type OmitZeroDuration struct {
+	Duration
+}
+
+// MarshalJSON satisfies the Marshaler interface, null is returned for a zero duration
+func (duration OmitZeroDuration) MarshalJSON() ([]byte, error) {
+	if duration.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return duration.Duration.MarshalJSON()
+}
+
+// UnmarshalJSON satisfies the Unmarshaler interface, null is read as a zero duration
+func (duration *OmitZeroDuration) UnmarshalJSON(source []byte) error {
+	if bytes.Equal(bytes.TrimSpace(source), []byte("null")) {
+		duration.Duration = Duration{}
+		return nil
+	}
+
+	return duration.Duration.UnmarshalJSON(source)
+}
+
+// DecodeArray decodes a JSON array of duration strings from r one element at a time, so large arrays
+// aren't held in memory as strings first. Each element is parsed with UnmarshalJSON.
+func DecodeArray(r io.Reader) ([]*Duration, error) {
+	decoder := json.NewDecoder(r)
+
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	if token != json.Delim('[') {
+		return nil, fmt.Errorf("expected a JSON array of durations, got %v", token)
+	}
+
+	var durations []*Duration
+	for decoder.More() {
+		duration := &Duration{}
+		if err := decoder.Decode(duration); err != nil {
+			return nil, fmt.Errorf("element %d: %w", len(durations), err)
+		}
+		durations = append(durations, duration)
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+
+	return durations, nil
+}
@@ -0,0 +1,85 @@
+package duration
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOmitZeroDuration_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		give OmitZeroDuration
+		want string
+	}{
+		{name: "zero", give: OmitZeroDuration{}, want: `{"d":null}`},
+		{name: "negative-zero", give: OmitZeroDuration{Duration{Negative: true}}, want: `{"d":null}`},
+		{name: "non-zero", give: OmitZeroDuration{Duration{Hours: 1, Minutes: 30}}, want: `{"d":"PT1H30M"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(struct {
+				Dur OmitZeroDuration `json:"d"`
+			}{Dur: tt.give})
+			if err != nil {
+				t.Fatalf("did not expect error: %s", err.Error())
+			}
+			if string(got) != tt.want {
+				t.Errorf("MarshalJSON() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOmitZeroDuration_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		give string
+		want OmitZeroDuration
+	}{
+		{name: "null", give: `{"d":null}`, want: OmitZeroDuration{}},
+		{name: "string", give: `{"d":"PT1H30M"}`, want: OmitZeroDuration{Duration{Hours: 1, Minutes: 30}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := struct {
+				Dur OmitZeroDuration `json:"d"`
+			}{Dur: OmitZeroDuration{Duration{Days: 1}}}
+			if err := json.Unmarshal([]byte(tt.give), &got); err != nil {
+				t.Fatalf("did not expect error: %s", err.Error())
+			}
+			if !reflect.DeepEqual(got.Dur, tt.want) {
+				t.Errorf("UnmarshalJSON() got = %v, want %v", got.Dur, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeArray(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    string
+		want    []*Duration
+		wantErr bool
+	}{
+		{name: "array", give: `["P1D","PT1H"]`, want: []*Duration{{Days: 1}, {Hours: 1}}},
+		{name: "empty", give: `[]`, want: nil},
+		{name: "null-element", give: `["P1D",null]`, want: []*Duration{{Days: 1}, {}}},
+		{name: "not-array", give: `"P1D"`, wantErr: true},
+		{name: "invalid-element", give: `["P1D","1X"]`, wantErr: true},
+		{name: "truncated", give: `["P1D"`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeArray(strings.NewReader(tt.give))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DecodeArray() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodeArray() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
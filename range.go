@@ -0,0 +1,62 @@
+package duration
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRange is returned by ParseRange when the input isn't a bounded range of two timestamps
+var ErrInvalidRange = errors.New("invalid range")
+
+// rangeLayouts are the timestamp formats postgres uses in tsrange and tstzrange text output, plus RFC 3339
+var rangeLayouts = []string{
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999-07",
+	"2006-01-02 15:04:05.999999999-07:00",
+	time.RFC3339Nano,
+}
+
+// ParseRange returns the duration covered by a postgres range of timestamps in its text form,
+// e.g. ["2021-01-01 10:00:00","2021-01-01 12:30:00") is PT2H30M. The duration is computed with Between
+// so it's calendar-aware. Whether the bounds are inclusive or exclusive makes no difference, but both
+// need to be present, empty and unbounded ranges return ErrInvalidRange.
+func ParseRange(s string) (*Duration, error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 2 || !strings.ContainsAny(s[:1], "[(") || !strings.ContainsAny(s[len(s)-1:], "])") {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidRange, s)
+	}
+
+	bounds := strings.Split(s[1:len(s)-1], ",")
+	if len(bounds) != 2 {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidRange, s)
+	}
+
+	lower, err := parseRangeBound(bounds[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %v", ErrInvalidRange, s, err)
+	}
+	upper, err := parseRangeBound(bounds[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %v", ErrInvalidRange, s, err)
+	}
+
+	return Between(lower, upper), nil
+}
+
+// parseRangeBound parses one bound of a range, which may be quoted
+func parseRangeBound(bound string) (time.Time, error) {
+	bound = strings.Trim(strings.TrimSpace(bound), `"`)
+	if bound == "" {
+		return time.Time{}, errors.New("unbounded")
+	}
+
+	for _, layout := range rangeLayouts {
+		if t, err := time.Parse(layout, bound); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unrecognised timestamp %q", bound)
+}
@@ -0,0 +1,41 @@
+package duration
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		give    string
+		want    string
+		wantErr bool
+	}{
+		{give: `["2021-01-01 10:00:00","2021-01-01 12:30:00")`, want: "PT2H30M"},
+		{give: `[2021-01-01 00:00:00,2021-02-02 06:00:00.5]`, want: "P1M1DT6H0.5S"},
+		{give: `("2021-01-01 10:00:00+00","2021-01-01 12:00:00+01")`, want: "PT1H"},
+		{give: `["2021-01-01T10:00:00Z","2021-01-02T10:00:00Z")`, want: "P1D"},
+		{give: `["2021-01-02 10:00:00","2021-01-01 10:00:00")`, want: "-P1D"},
+		{give: `["2021-01-01 10:00:00",)`, wantErr: true},
+		{give: `empty`, wantErr: true},
+		{give: `["yesterday","today")`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			got, err := ParseRange(tt.give)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseRange() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidRange) {
+					t.Errorf("ParseRange() error = %v, want %v", err, ErrInvalidRange)
+				}
+				return
+			}
+			if got.String() != tt.want {
+				t.Errorf("ParseRange() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,290 @@
+package duration
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// SignedDuration is an ISO 8601-2 duration where each unit carries its own sign,
+// e.g. P1Y-2M is one year minus two months.
+type SignedDuration struct {
+	Years   float64
+	Months  float64
+	Weeks   float64
+	Days    float64
+	Hours   float64
+	Minutes float64
+	Seconds float64
+}
+
+// ParseSigned attempts to parse the given ISO 8601-2 duration string, which may
+// have signed components (e.g. P1Y-2M or -P1DT-2H), into a *SignedDuration,
+// if parsing fails a *ParseError is returned instead.
+// A sign in front of the P applies to every component.
+func ParseSigned(d string) (*SignedDuration, error) {
+	duration := &SignedDuration{}
+	input := d
+	start := 0
+	offset := 0
+	state := parsingPeriod
+	negative := false
+	var seen, unit uint8
+
+	fail := func(pos int, err error) (*SignedDuration, error) {
+		return nil, &ParseError{Input: input, Pos: offset + pos, Err: err}
+	}
+
+	switch {
+	case strings.HasPrefix(d, "-"):
+		negative = true
+		d = strings.TrimPrefix(d, "-")
+		offset++
+	case strings.HasPrefix(d, "+"):
+		d = strings.TrimPrefix(d, "+")
+		offset++
+	}
+
+	if !strings.HasPrefix(d, "P") {
+		return fail(0, ErrMissingPrefix)
+	}
+	d = strings.TrimPrefix(d, "P")
+	offset++
+
+	for i, char := range d {
+		num := d[start:i]
+		var field *float64
+
+		switch char {
+		case 'T':
+			if state == parsingTime || num != "" {
+				return fail(i, fmt.Errorf("%w: %q", ErrUnexpectedInput, char))
+			}
+			state = parsingTime
+			start = i + 1
+			continue
+		case 'Y':
+			field, unit = &duration.Years, unitYears
+		case 'M':
+			field, unit = &duration.Months, unitMonths
+			if state == parsingTime {
+				field, unit = &duration.Minutes, unitMinutes
+			}
+		case 'W':
+			field, unit = &duration.Weeks, unitWeeks
+		case 'D':
+			field, unit = &duration.Days, unitDays
+		case 'H':
+			field, unit = &duration.Hours, unitHours
+		case 'S':
+			field, unit = &duration.Seconds, unitSeconds
+		default:
+			if unicode.IsNumber(char) || char == '.' || ((char == '-' || char == '+') && num == "") {
+				continue
+			}
+
+			return fail(i, fmt.Errorf("%w: %q", ErrUnexpectedInput, char))
+		}
+
+		isTime := unit >= unitHours
+		if isTime != (state == parsingTime) {
+			return fail(i, fmt.Errorf("%w: %q", ErrUnexpectedInput, char))
+		}
+
+		value, err := strconv.ParseFloat(num, 64)
+		if err != nil {
+			return fail(start, err)
+		}
+		// a repeated unit would silently overwrite the earlier value
+		if seen&unit != 0 {
+			return fail(i, fmt.Errorf("%w: %q", ErrDuplicateUnit, char))
+		}
+		seen |= unit
+		*field = value
+		start = i + 1
+	}
+
+	if start < len(d) {
+		return fail(start, fmt.Errorf("%w: trailing number without a unit", ErrUnexpectedInput))
+	}
+	if state == parsingTime && seen&(unitHours|unitMinutes|unitSeconds) == 0 {
+		return fail(len(d), ErrDanglingTimeSeparator)
+	}
+	if seen == 0 {
+		return fail(len(d), ErrEmptyInput)
+	}
+
+	if negative {
+		duration = duration.Neg()
+	}
+
+	return duration, nil
+}
+
+// Neg returns a copy of the *SignedDuration with every component's sign flipped
+func (duration *SignedDuration) Neg() *SignedDuration {
+	return &SignedDuration{
+		Years:   -duration.Years,
+		Months:  -duration.Months,
+		Weeks:   -duration.Weeks,
+		Days:    -duration.Days,
+		Hours:   -duration.Hours,
+		Minutes: -duration.Minutes,
+		Seconds: -duration.Seconds,
+	}
+}
+
+// ToTimeDuration converts the *SignedDuration to the net time.Duration of its components,
+// years and months count the same as in Duration.ToTimeDuration.
+func (duration *SignedDuration) ToTimeDuration() time.Duration {
+	var timeDuration time.Duration
+
+	timeDuration += time.Duration(math.Round(duration.Years * nsPerYear))
+	timeDuration += time.Duration(math.Round(duration.Months * nsPerMonth))
+	timeDuration += time.Duration(math.Round(duration.Weeks * nsPerWeek))
+	timeDuration += time.Duration(math.Round(duration.Days * nsPerDay))
+	timeDuration += time.Duration(math.Round(duration.Hours * nsPerHour))
+	timeDuration += time.Duration(math.Round(duration.Minutes * nsPerMinute))
+	timeDuration += time.Duration(math.Round(duration.Seconds * nsPerSecond))
+
+	return timeDuration
+}
+
+// String returns the ISO 8601-2 duration string for the *SignedDuration, e.g. P1Y-2M
+func (duration *SignedDuration) String() string {
+	d := "P"
+	hasTime := false
+
+	appendD := func(designator string, value float64, isTime bool) {
+		if value == 0 {
+			return
+		}
+
+		if !hasTime && isTime {
+			d += "T"
+			hasTime = true
+		}
+
+		d += formatFloat(value) + designator
+	}
+
+	appendD("Y", duration.Years, false)
+	appendD("M", duration.Months, false)
+	appendD("W", duration.Weeks, false)
+	appendD("D", duration.Days, false)
+	appendD("H", duration.Hours, true)
+	appendD("M", duration.Minutes, true)
+	appendD("S", duration.Seconds, true)
+
+	if d == "P" {
+		return "PT0S"
+	}
+
+	return d
+}
+
+// signed returns the *Duration as a *SignedDuration, moving its sign onto every unit
+func (duration *Duration) signed() *SignedDuration {
+	signed := &SignedDuration{
+		Years:   duration.Years,
+		Months:  duration.Months,
+		Weeks:   duration.Weeks,
+		Days:    duration.Days,
+		Hours:   duration.Hours,
+		Minutes: duration.Minutes,
+		Seconds: duration.Seconds,
+	}
+	if duration.Negative {
+		return signed.Neg()
+	}
+
+	return signed
+}
+
+// unsigned returns the *SignedDuration as a *Duration, it's negative when no unit is positive or, when the units
+// disagree, when the net total is negative. Units disagreeing with that sign are resolved with borrow, so the
+// result always formats as a valid duration string, e.g. P1Y-6M becomes P6M.
+func (duration *SignedDuration) unsigned() *Duration {
+	values := []float64{
+		duration.Years, duration.Months, duration.Weeks, duration.Days,
+		duration.Hours, duration.Minutes, duration.Seconds,
+	}
+	positive, negative := false, false
+	for _, value := range values {
+		positive = positive || value > 0
+		negative = negative || value < 0
+	}
+
+	flip := negative && (!positive || duration.ToTimeDuration() < 0)
+	if flip {
+		for i := range values {
+			values[i] = -values[i]
+		}
+	}
+	if positive && negative {
+		borrow(values)
+	}
+
+	return &Duration{
+		Years:    values[0],
+		Months:   values[1],
+		Weeks:    values[2],
+		Days:     values[3],
+		Hours:    values[4],
+		Minutes:  values[5],
+		Seconds:  values[6],
+		Negative: flip,
+	}
+}
+
+// secondsPerUnit holds the lengths in seconds of the units from years to seconds, using the fuzzy month and year
+var secondsPerUnit = []float64{
+	hoursPerYear * 3600, hoursPerMonth * 3600, hoursPerWeek * 3600, hoursPerDay * 3600, 3600, 60, 1,
+}
+
+// borrow makes the unit values (years first) non-negative when their total isn't, like in written subtraction
+// a negative unit borrows whole units from the nearest larger positive ones and the rest is spread over the units
+// in between, e.g. 1 day and -1 hour become 23 hours. When the larger units can't cover it the smaller units are
+// folded in as well, e.g. -1 hour and 90 minutes become 30 minutes.
+func borrow(values []float64) {
+	last := len(values) - 1
+	for i := last; i >= 0; i-- {
+		if values[i] >= 0 {
+			continue
+		}
+
+		amount := values[i] * secondsPerUnit[i]
+		values[i] = 0
+		j := i - 1
+		for ; j >= 0 && amount < 0; j-- {
+			take := values[j]
+			if take > 0 {
+				take = math.Min(math.Ceil(-amount/secondsPerUnit[j]), take)
+			}
+			values[j] -= take
+			amount += take * secondsPerUnit[j]
+		}
+
+		// j+1 is the last unit borrowed from, what's left after covering unit i goes to the units below it
+		from, to := j+2, i
+		if amount < 0 {
+			for k := i + 1; k <= last; k++ {
+				amount += values[k] * secondsPerUnit[k]
+				values[k] = 0
+			}
+			from, to = i, last
+		}
+
+		// float noise can leave a tiny negative amount when the total is zero
+		amount = math.Max(amount, 0)
+		for k := from; k < to; k++ {
+			whole := math.Floor(amount / secondsPerUnit[k])
+			values[k] += whole
+			amount -= whole * secondsPerUnit[k]
+		}
+		values[to] += amount / secondsPerUnit[to]
+	}
+}
@@ -0,0 +1,121 @@
+package duration
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseSigned(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    string
+		want    *SignedDuration
+		total   time.Duration
+		str     string
+		wantErr bool
+	}{
+		{
+			name:  "year-minus-months",
+			give:  "P1Y-2M",
+			want:  &SignedDuration{Years: 1, Months: -2},
+			total: nsPerYear - 2*nsPerMonth,
+			str:   "P1Y-2M",
+		},
+		{
+			name:  "negative-time",
+			give:  "P1DT-2H30M",
+			want:  &SignedDuration{Days: 1, Hours: -2, Minutes: 30},
+			total: 22*time.Hour + 30*time.Minute,
+			str:   "P1DT-2H30M",
+		},
+		{
+			name:  "leading-sign",
+			give:  "-P1DT-2H",
+			want:  &SignedDuration{Days: -1, Hours: 2},
+			total: -22 * time.Hour,
+			str:   "P-1DT2H",
+		},
+		{
+			name:    "no-prefix",
+			give:    "1Y-2M",
+			wantErr: true,
+		},
+		{
+			name:    "dangling-time",
+			give:    "P1DT",
+			wantErr: true,
+		},
+		{
+			name:    "sign-only",
+			give:    "P-D",
+			wantErr: true,
+		},
+		{
+			name:    "duplicate-unit",
+			give:    "P1D1D",
+			wantErr: true,
+		},
+		{
+			name:    "trailing-number",
+			give:    "P1D2",
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			give:    "P",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSigned(tt.give)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseSigned() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSigned() got = %v, want %v", got, tt.want)
+			}
+			if got.ToTimeDuration() != tt.total {
+				t.Errorf("ToTimeDuration() got = %v, want %v", got.ToTimeDuration(), tt.total)
+			}
+			if got.String() != tt.str {
+				t.Errorf("String() got = %s, want %s", got.String(), tt.str)
+			}
+		})
+	}
+}
+
+func TestParseSigned_Error(t *testing.T) {
+	tests := []struct {
+		give    string
+		wantErr error
+		wantPos int
+	}{
+		{give: "1Y-2M", wantErr: ErrMissingPrefix, wantPos: 0},
+		{give: "P1D1D", wantErr: ErrDuplicateUnit, wantPos: 4},
+		{give: "-P1DT-2H-3H", wantErr: ErrDuplicateUnit, wantPos: 10},
+		{give: "P1DX", wantErr: ErrUnexpectedInput, wantPos: 3},
+		{give: "P1DT", wantErr: ErrDanglingTimeSeparator, wantPos: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			_, err := ParseSigned(tt.give)
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("ParseSigned() error = %v, want a *ParseError", err)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseSigned() error = %v, want %v", err, tt.wantErr)
+			}
+			if parseErr.Input != tt.give || parseErr.Pos != tt.wantPos {
+				t.Errorf("ParseSigned() error at %q position %d, want %q position %d", parseErr.Input, parseErr.Pos, tt.give, tt.wantPos)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package duration
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// SubsecondNanos returns the fractional part of the Seconds field as nanoseconds (0 to 999,999,999),
+// negated for negative durations. Only the Seconds field is looked at, fractions of larger units aren't carried down.
+func (duration *Duration) SubsecondNanos() int64 {
+	_, fraction := math.Modf(duration.Seconds)
+	nanos := int64(math.Round(fraction * nsPerSecond))
+	// rounding can push something like 0.9999999999 up to a whole second
+	if nanos >= nsPerSecond {
+		nanos = nsPerSecond - 1
+	}
+
+	if duration.Negative {
+		return -nanos
+	}
+
+	return nanos
+}
+
+// BusinessDays converts the total time of the *Duration into a count of business days that are workingHours long,
+// e.g. PT40H is 5 business days of 8 hours. It's purely arithmetic, weekends and holidays aren't taken into account.
+// Zero is returned when workingHours isn't positive.
+func (duration *Duration) BusinessDays(workingHours float64) float64 {
+	if workingHours <= 0 {
+		return 0
+	}
+
+	return duration.ToTimeDuration().Hours() / workingHours
+}
+
+// Std is a short alias for ToTimeDuration, the methods named like time.Duration's would clash with the
+// *Duration fields so they're reached through it instead, e.g. d.Std().Hours() or d.Std().Milliseconds().
+func (duration *Duration) Std() time.Duration {
+	return duration.ToTimeDuration()
+}
+
+// PerMinute returns how many times the *Duration occurs in a minute, e.g. 4 for PT15S.
+// The sign is ignored and zero is returned for a zero duration.
+func (duration *Duration) PerMinute() float64 {
+	return duration.per(time.Minute)
+}
+
+// PerHour returns how many times the *Duration occurs in an hour, e.g. 40 for PT90S.
+// The sign is ignored and zero is returned for a zero duration.
+func (duration *Duration) PerHour() float64 {
+	return duration.per(time.Hour)
+}
+
+// PerDay returns how many times the *Duration occurs in a day, e.g. 96 for PT15M.
+// The sign is ignored and zero is returned for a zero duration.
+func (duration *Duration) PerDay() float64 {
+	return duration.per(nsPerDay)
+}
+
+// per returns how many times the *Duration's magnitude fits into period
+func (duration *Duration) per(period time.Duration) float64 {
+	total := duration.ToTimeDuration()
+	if total < 0 {
+		total = -total
+	}
+	if total == 0 {
+		return 0
+	}
+
+	return float64(period) / float64(total)
+}
+
+// PromSeconds returns the signed total of the *Duration in seconds, the base unit Prometheus and
+// OpenMetrics expect for _seconds metrics, e.g. 5400 for PT1H30M.
+func (duration *Duration) PromSeconds() float64 {
+	return duration.ToTimeDuration().Seconds()
+}
+
+// wholeUnitLengths maps the unit names accepted by WholeUnits, IsWholeUnit and FormatCapped to their length
+// in nanoseconds
+var wholeUnitLengths = map[string]int64{
+	"years":   nsPerYear,
+	"months":  nsPerMonth,
+	"weeks":   nsPerWeek,
+	"days":    nsPerDay,
+	"hours":   nsPerHour,
+	"minutes": nsPerMinute,
+	"seconds": nsPerSecond,
+}
+
+// WholeUnits returns how many whole units fit in the total of the *Duration, e.g. 1 for "hours" and
+// 90 for "minutes" on PT1H30M. The unit is one of years, months, weeks, days, hours, minutes or seconds,
+// zero is returned for anything else. Negative durations give a negative count.
+func (duration *Duration) WholeUnits(unit string) int64 {
+	length, ok := wholeUnitLengths[unit]
+	if !ok {
+		return 0
+	}
+
+	return int64(duration.ToTimeDuration()) / length
+}
+
+// IsWholeUnit reports whether the total of the *Duration is an exact multiple of unit, e.g. P2D is whole "days"
+// but PT25H isn't. The units are the same as for WholeUnits and it's false for an unknown unit.
+func (duration *Duration) IsWholeUnit(unit string) bool {
+	length, ok := wholeUnitLengths[unit]
+	return ok && int64(duration.ToTimeDuration())%length == 0
+}
+
+// PayPeriod returns the *Duration as whole weeks and days for payroll periods, e.g. 2 weeks and 2 days for P16D.
+// Any time of day left over is dropped, see PayPeriodExact to reject it, and ErrFuzzyDuration is returned when
+// years or months are set since their number of days is ambiguous. Both values are negative for negative durations.
+func (duration *Duration) PayPeriod() (weeks int, days int, err error) {
+	return duration.payPeriod(false)
+}
+
+// PayPeriodExact is like PayPeriod but returns an error wrapping ErrUnexpectedInput rather than dropping time
+// left over below a day, e.g. for PT49H.
+func (duration *Duration) PayPeriodExact() (weeks int, days int, err error) {
+	return duration.payPeriod(true)
+}
+
+// payPeriod implements PayPeriod and PayPeriodExact
+func (duration *Duration) payPeriod(exact bool) (weeks int, days int, err error) {
+	total, err := duration.ToTimeDurationExact()
+	if err != nil {
+		return 0, 0, err
+	}
+	if exact && total%nsPerDay != 0 {
+		return 0, 0, fmt.Errorf("%w: %s isn't a whole number of days", ErrUnexpectedInput, duration)
+	}
+
+	totalDays := int(total / nsPerDay)
+	return totalDays / 7, totalDays % 7, nil
+}
+
+// Ticker returns a time.Ticker ticking every ToTimeDuration of the *Duration, or ErrNonPositiveStep instead of the
+// panic from time.NewTicker when the duration isn't positive. The caller has to Stop the ticker.
+func (duration *Duration) Ticker() (*time.Ticker, error) {
+	interval := duration.ToTimeDuration()
+	if interval <= 0 {
+		return nil, ErrNonPositiveStep
+	}
+
+	return time.NewTicker(interval), nil
+}
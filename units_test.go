@@ -0,0 +1,232 @@
+package duration
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDuration_SubsecondNanos(t *testing.T) {
+	tests := []struct {
+		name string
+		give *Duration
+		want int64
+	}{
+		{name: "quarter", give: &Duration{Seconds: 1.25}, want: 250000000},
+		{name: "whole", give: &Duration{Minutes: 1.5, Seconds: 3}, want: 0},
+		{name: "nanosecond", give: &Duration{Seconds: 0.000000001}, want: 1},
+		{name: "negative", give: &Duration{Seconds: 2.5, Negative: true}, want: -500000000},
+		{name: "rounding", give: &Duration{Seconds: 0.9999999999}, want: 999999999},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.give.SubsecondNanos(); got != tt.want {
+				t.Errorf("SubsecondNanos() got = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_BusinessDays(t *testing.T) {
+	tests := []struct {
+		name         string
+		give         *Duration
+		workingHours float64
+		want         float64
+	}{
+		{name: "8h-days", give: &Duration{Hours: 40}, workingHours: 8, want: 5},
+		{name: "7.5h-days", give: &Duration{Hours: 30}, workingHours: 7.5, want: 4},
+		{name: "24h-days", give: &Duration{Days: 2, Hours: 12}, workingHours: 24, want: 2.5},
+		{name: "negative", give: &Duration{Hours: 4, Negative: true}, workingHours: 8, want: -0.5},
+		{name: "invalid", give: &Duration{Hours: 4}, workingHours: 0, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.give.BusinessDays(tt.workingHours); got != tt.want {
+				t.Errorf("BusinessDays() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_Std(t *testing.T) {
+	duration := &Duration{Hours: 1, Minutes: 30, Seconds: 0.5}
+
+	if got := duration.Std(); got != duration.ToTimeDuration() {
+		t.Errorf("Std() got = %v, want %v", got, duration.ToTimeDuration())
+	}
+	if got := duration.Std().Hours(); got != 1.5+0.5/3600 {
+		t.Errorf("Std().Hours() got = %v, want %v", got, 1.5+0.5/3600)
+	}
+	if got := duration.Std().Milliseconds(); got != int64(90*time.Minute/time.Millisecond)+500 {
+		t.Errorf("Std().Milliseconds() got = %v", got)
+	}
+}
+
+func TestDuration_PerHour(t *testing.T) {
+	tests := []struct {
+		name                       string
+		give                       *Duration
+		perMinute, perHour, perDay float64
+	}{
+		{name: "90-seconds", give: &Duration{Seconds: 90}, perMinute: 2.0 / 3, perHour: 40, perDay: 960},
+		{name: "15-minutes", give: &Duration{Minutes: 15}, perMinute: 1.0 / 15, perHour: 4, perDay: 96},
+		{name: "negative", give: &Duration{Seconds: 15, Negative: true}, perMinute: 4, perHour: 240, perDay: 5760},
+		{name: "zero", give: &Duration{}, perMinute: 0, perHour: 0, perDay: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.give.PerMinute(); got != tt.perMinute {
+				t.Errorf("PerMinute() got = %v, want %v", got, tt.perMinute)
+			}
+			if got := tt.give.PerHour(); got != tt.perHour {
+				t.Errorf("PerHour() got = %v, want %v", got, tt.perHour)
+			}
+			if got := tt.give.PerDay(); got != tt.perDay {
+				t.Errorf("PerDay() got = %v, want %v", got, tt.perDay)
+			}
+		})
+	}
+}
+
+func TestDuration_PromSeconds(t *testing.T) {
+	tests := []struct {
+		give *Duration
+		want float64
+	}{
+		{give: &Duration{Hours: 1, Minutes: 30}, want: 5400},
+		{give: &Duration{Seconds: 0.25}, want: 0.25},
+		{give: &Duration{Days: 1, Negative: true}, want: -86400},
+		{give: &Duration{}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give.String(), func(t *testing.T) {
+			if got := tt.give.PromSeconds(); got != tt.want {
+				t.Errorf("PromSeconds() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_WholeUnits(t *testing.T) {
+	tests := []struct {
+		name string
+		give *Duration
+		unit string
+		want int64
+	}{
+		{name: "hours", give: &Duration{Hours: 1, Minutes: 30}, unit: "hours", want: 1},
+		{name: "minutes", give: &Duration{Hours: 1, Minutes: 30}, unit: "minutes", want: 90},
+		{name: "days", give: &Duration{Weeks: 1, Days: 2, Hours: 23}, unit: "days", want: 9},
+		{name: "too-small", give: &Duration{Minutes: 59}, unit: "hours", want: 0},
+		{name: "negative", give: &Duration{Hours: 1, Minutes: 30, Negative: true}, unit: "minutes", want: -90},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.give.WholeUnits(tt.unit); got != tt.want {
+				t.Errorf("WholeUnits() got = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	if got := (&Duration{Hours: 1}).WholeUnits("fortnights"); got != 0 {
+		t.Errorf("WholeUnits() got = %d for an unknown unit, want 0", got)
+	}
+}
+
+func TestDuration_IsWholeUnit(t *testing.T) {
+	tests := []struct {
+		name string
+		give *Duration
+		unit string
+		want bool
+	}{
+		{name: "whole-days", give: &Duration{Days: 2}, unit: "days", want: true},
+		{name: "hours-not-days", give: &Duration{Hours: 25}, unit: "days", want: false},
+		{name: "hours-as-days", give: &Duration{Hours: 48}, unit: "days", want: true},
+		{name: "week-in-days", give: &Duration{Weeks: 1}, unit: "days", want: true},
+		{name: "fractional-minutes", give: &Duration{Minutes: 1.5}, unit: "minutes", want: false},
+		{name: "negative", give: &Duration{Days: 3, Negative: true}, unit: "days", want: true},
+		{name: "zero", give: &Duration{}, unit: "hours", want: true},
+		{name: "unknown", give: &Duration{}, unit: "fortnights", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.give.IsWholeUnit(tt.unit); got != tt.want {
+				t.Errorf("IsWholeUnit() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_PayPeriod(t *testing.T) {
+	tests := []struct {
+		name        string
+		give        *Duration
+		weeks, days int
+		wantErr     error
+	}{
+		{name: "days", give: &Duration{Days: 16}, weeks: 2, days: 2},
+		{name: "weeks-and-days", give: &Duration{Weeks: 1, Days: 9}, weeks: 2, days: 2},
+		{name: "hours", give: &Duration{Hours: 49}, weeks: 0, days: 2},
+		{name: "negative", give: &Duration{Days: 10, Negative: true}, weeks: -1, days: -3},
+		{name: "months", give: &Duration{Months: 1}, wantErr: ErrFuzzyDuration},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weeks, days, err := tt.give.PayPeriod()
+			if err != tt.wantErr {
+				t.Fatalf("PayPeriod() error = %v, want %v", err, tt.wantErr)
+			}
+			if weeks != tt.weeks || days != tt.days {
+				t.Errorf("PayPeriod() got = %d weeks %d days, want %d weeks %d days", weeks, days, tt.weeks, tt.days)
+			}
+		})
+	}
+}
+
+func TestDuration_PayPeriodExact(t *testing.T) {
+	tests := []struct {
+		name        string
+		give        *Duration
+		weeks, days int
+		wantErr     error
+	}{
+		{name: "days", give: &Duration{Days: 16}, weeks: 2, days: 2},
+		{name: "whole-hours", give: &Duration{Hours: 48}, weeks: 0, days: 2},
+		{name: "hours", give: &Duration{Hours: 49}, wantErr: ErrUnexpectedInput},
+		{name: "negative", give: &Duration{Days: 10, Seconds: 1, Negative: true}, wantErr: ErrUnexpectedInput},
+		{name: "months", give: &Duration{Months: 1}, wantErr: ErrFuzzyDuration},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weeks, days, err := tt.give.PayPeriodExact()
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("PayPeriodExact() error = %v, want %v", err, tt.wantErr)
+			}
+			if weeks != tt.weeks || days != tt.days {
+				t.Errorf("PayPeriodExact() got = %d weeks %d days, want %d weeks %d days", weeks, days, tt.weeks, tt.days)
+			}
+		})
+	}
+}
+
+func TestDuration_Ticker(t *testing.T) {
+	ticker, err := (&Duration{Seconds: 0.001}).Ticker()
+	if err != nil {
+		t.Fatalf("did not expect error: %s", err.Error())
+	}
+	defer ticker.Stop()
+
+	select {
+	case <-ticker.C:
+	case <-time.After(time.Second):
+		t.Errorf("Ticker() didn't tick within a second")
+	}
+
+	for _, d := range []*Duration{{}, {Minutes: 1, Negative: true}, {Seconds: 1e-12}} {
+		if _, err := d.Ticker(); err != ErrNonPositiveStep {
+			t.Errorf("Ticker() error = %v, want %v", err, ErrNonPositiveStep)
+		}
+	}
+}
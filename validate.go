@@ -0,0 +1,212 @@
+package duration
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	// ErrMissingPrefix is returned when a duration string doesn't start with the P designator
+	ErrMissingPrefix = errors.New("missing P designator")
+	// ErrEmptyValue is returned when a unit designator isn't preceded by a number, e.g. PD
+	ErrEmptyValue = errors.New("unit has no value")
+	// ErrUnitOrder is returned when units aren't in the order years, months, weeks, days, hours, minutes, seconds
+	ErrUnitOrder = errors.New("unit out of order")
+	// ErrWeeksMixed is returned when weeks are combined with other units, which strict ISO 8601 doesn't allow
+	ErrWeeksMixed = errors.New("weeks combined with other units")
+)
+
+// Validate checks the given duration string against strict ISO 8601 and returns every problem found
+// rather than stopping at the first one, so a UI can show them all at once. On top of what Parse rejects it
+// reports a missing P, lowercase designators, units out of order and weeks combined with other units.
+// A valid string returns nil.
+func Validate(d string) []error {
+	var errs []error
+	report := func(err error, char rune, pos int) {
+		errs = append(errs, fmt.Errorf("%w: %q at position %d", err, char, pos))
+	}
+
+	offset := len(d) - len(strings.TrimLeftFunc(d, unicode.IsSpace))
+	d = strings.TrimSpace(d)
+	if strings.HasPrefix(d, "-") {
+		d = d[1:]
+		offset++
+	}
+
+	iso := strings.HasPrefix(d, "P")
+	if iso {
+		d = d[1:]
+		offset++
+	} else {
+		errs = append(errs, ErrMissingPrefix)
+	}
+
+	state := parsingPeriod
+	start := 0
+	last := -1
+	var seen uint8
+
+	for i, char := range d {
+		num := d[start:i]
+		pos := offset + i
+		var unit uint8
+
+		switch {
+		case char == 'T' && iso:
+			if state == parsingTime {
+				report(ErrUnexpectedInput, char, pos)
+			}
+			if num != "" {
+				report(fmt.Errorf("%w: number without a unit", ErrUnexpectedInput), char, pos)
+			}
+			state = parsingTime
+			start = i + 1
+			continue
+		case char == 'Y' || char == 'y':
+			unit = unitYears
+		case char == 'M' && !(iso && state == parsingTime):
+			unit = unitMonths
+		case char == 'M' || char == 'm':
+			unit = unitMinutes
+		case char == 'W' || char == 'w':
+			unit = unitWeeks
+		case char == 'D' || char == 'd':
+			unit = unitDays
+		case char == 'H' || char == 'h':
+			unit = unitHours
+		case char == 'S' || char == 's':
+			unit = unitSeconds
+		case unicode.IsNumber(char) || char == '.':
+			continue
+		default:
+			report(ErrUnexpectedInput, char, pos)
+			start = i + len(string(char))
+			continue
+		}
+		start = i + 1
+
+		// Parse accepts lowercase designators, strict ISO 8601 only has uppercase ones
+		if unicode.IsLower(char) {
+			report(fmt.Errorf("%w: lowercase designator", ErrUnexpectedInput), char, pos)
+		}
+		if num == "" {
+			report(ErrEmptyValue, char, pos)
+		} else if _, err := strconv.ParseFloat(num, 64); err != nil {
+			report(err, char, pos)
+		}
+
+		isTime := unit >= unitHours
+		if iso && isTime != (state == parsingTime) {
+			report(fmt.Errorf("%w: unit in the wrong section", ErrUnexpectedInput), char, pos)
+		}
+
+		index := unitIndex(unit)
+		switch {
+		case seen&unit != 0:
+			report(ErrDuplicateUnit, char, pos)
+		case index < last:
+			report(ErrUnitOrder, char, pos)
+		}
+		if index > last {
+			last = index
+		}
+		seen |= unit
+	}
+
+	if start < len(d) && strings.TrimFunc(d[start:], func(r rune) bool { return unicode.IsNumber(r) || r == '.' }) == "" {
+		errs = append(errs, fmt.Errorf("%w: trailing number without a unit", ErrUnexpectedInput))
+	}
+	if state == parsingTime && seen&(unitHours|unitMinutes|unitSeconds) == 0 {
+		errs = append(errs, ErrDanglingTimeSeparator)
+	}
+	if seen == 0 {
+		errs = append(errs, fmt.Errorf("%w: no units", ErrUnexpectedInput))
+	}
+	if seen&unitWeeks != 0 && seen&^unitWeeks != 0 {
+		errs = append(errs, ErrWeeksMixed)
+	}
+
+	return errs
+}
+
+// unitIndex returns the position of the unit bit in the ISO 8601 order, years first
+func unitIndex(unit uint8) int {
+	index := 0
+	for unit > 1 {
+		unit >>= 1
+		index++
+	}
+	return index
+}
+
+// ParseWithWarnings is Parse for linting tools, on top of the *Duration it returns non-fatal warnings about input
+// that parses but loses information later, namely sub-nanosecond precision that ToTimeDuration rounds away and
+// fuzzy years or months. Errors are the same as for Parse.
+func ParseWithWarnings(s string) (*Duration, []string, error) {
+	duration, err := Parse(s)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var warnings []string
+	nanos := []float64{
+		duration.Years * nsPerYear, duration.Months * nsPerMonth, duration.Weeks * nsPerWeek, duration.Days * nsPerDay,
+		duration.Hours * nsPerHour, duration.Minutes * nsPerMinute, duration.Seconds * nsPerSecond,
+	}
+	for _, ns := range nanos {
+		// allow for float noise, anything below a thousandth of a nanosecond isn't a real fraction
+		if math.Abs(ns-math.Round(ns)) > 1e-3 {
+			warnings = append(warnings, "sub-nanosecond precision will be lost in time.Duration conversion")
+			break
+		}
+	}
+	if duration.Years != 0 || duration.Months != 0 {
+		warnings = append(warnings, "contains fuzzy month/year components")
+	}
+
+	return duration, warnings, nil
+}
+
+// ConformanceIssues reports the strict ISO 8601 rules the *Duration would break when written out with String,
+// which matters for durations parsed leniently or built with arithmetic. It checks for weeks combined with other
+// units, fractions on any unit but the smallest one and negative unit values. A conformant duration returns nil.
+func (duration *Duration) ConformanceIssues() []string {
+	var issues []string
+	values := []float64{
+		duration.Years, duration.Months, duration.Weeks, duration.Days,
+		duration.Hours, duration.Minutes, duration.Seconds,
+	}
+
+	last := -1
+	for i, value := range values {
+		if value != 0 {
+			last = i
+		}
+	}
+
+	mixedWeeks, fractional, negative := false, false, false
+	for i, value := range values {
+		if value == 0 {
+			continue
+		}
+		mixedWeeks = mixedWeeks || duration.Weeks != 0 && i != 2
+		fractional = fractional || i != last && value != math.Trunc(value)
+		negative = negative || value < 0
+	}
+
+	if mixedWeeks {
+		issues = append(issues, ErrWeeksMixed.Error())
+	}
+	if fractional {
+		issues = append(issues, "fraction on a unit other than the smallest")
+	}
+	if negative {
+		issues = append(issues, "negative unit value")
+	}
+
+	return issues
+}
@@ -0,0 +1,102 @@
+package duration
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		give string
+		want []error
+	}{
+		{give: "P3Y6M4DT12H30M5.5S", want: nil},
+		{give: "-P2W", want: nil},
+		{give: "PT90M", want: nil},
+		{give: "P1D1Y2D1W", want: []error{ErrUnitOrder, ErrDuplicateUnit, ErrUnitOrder, ErrWeeksMixed}},
+		{give: "4Y", want: []error{ErrMissingPrefix}},
+		{give: "PDT1H", want: []error{ErrEmptyValue}},
+		{give: "P1Dx2H", want: []error{ErrUnexpectedInput, ErrUnexpectedInput}},
+		{give: "P1DT", want: []error{ErrDanglingTimeSeparator}},
+		{give: "P3", want: []error{ErrUnexpectedInput, ErrUnexpectedInput}},
+		{give: "", want: []error{ErrMissingPrefix, ErrUnexpectedInput}},
+		{give: "P1d", want: []error{ErrUnexpectedInput}},
+		{give: "PT5m", want: []error{ErrUnexpectedInput}},
+		{give: "P1Dt5H", want: []error{ErrUnexpectedInput, ErrUnexpectedInput}},
+		{give: "5m", want: []error{ErrMissingPrefix, ErrUnexpectedInput}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			got := Validate(tt.give)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Validate() got = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !errors.Is(got[i], tt.want[i]) {
+					t.Errorf("Validate() error %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+
+	errs := Validate("P1D1Y")
+	if len(errs) != 1 || errs[0].Error() != `unit out of order: 'Y' at position 4` {
+		t.Errorf("Validate() got = %v", errs)
+	}
+}
+
+func TestParseWithWarnings(t *testing.T) {
+	tests := []struct {
+		give    string
+		want    []string
+		wantErr bool
+	}{
+		{give: "0.0000000001S", want: []string{"sub-nanosecond precision will be lost in time.Duration conversion"}},
+		{give: "P1M", want: []string{"contains fuzzy month/year components"}},
+		{give: "P1Y2M3DT0.0000000005S", want: []string{
+			"sub-nanosecond precision will be lost in time.Duration conversion",
+			"contains fuzzy month/year components",
+		}},
+		{give: "PT1.1S", want: nil},
+		{give: "P1DT12H", want: nil},
+		{give: "P1X", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			got, warnings, err := ParseWithWarnings(tt.give)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseWithWarnings() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got == nil {
+				t.Errorf("ParseWithWarnings() got = nil, want a duration")
+			}
+			if !reflect.DeepEqual(warnings, tt.want) {
+				t.Errorf("ParseWithWarnings() warnings = %q, want %q", warnings, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_ConformanceIssues(t *testing.T) {
+	tests := []struct {
+		name string
+		give *Duration
+		want []string
+	}{
+		{name: "week-and-day", give: &Duration{Weeks: 1, Days: 2}, want: []string{"weeks combined with other units"}},
+		{name: "fraction-not-last", give: &Duration{Days: 1.5, Hours: 2}, want: []string{"fraction on a unit other than the smallest"}},
+		{name: "fraction-last", give: &Duration{Days: 1, Hours: 2.5}, want: nil},
+		{name: "negative-unit", give: &Duration{Years: 1, Months: -6}, want: []string{"negative unit value"}},
+		{name: "weeks-alone", give: &Duration{Weeks: 2, Negative: true}, want: nil},
+		{name: "zero", give: &Duration{}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.give.ConformanceIssues(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ConformanceIssues() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}